@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/cli"
 	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/tui"
 )
 
@@ -18,6 +19,12 @@ func main() {
 	}
 
 	basePath, _ := os.Getwd()
+
+	if len(os.Args) > 1 {
+		cli.Version = tui.Version
+		os.Exit(cli.Run(basePath, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	p := tea.NewProgram(tui.NewModel(basePath), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
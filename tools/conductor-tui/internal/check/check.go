@@ -0,0 +1,230 @@
+// Package check validates tracks against completion policies for use as a
+// CI gate.
+package check
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Severity controls how a rule's violations are reported.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Rule is a single completion policy.
+type Rule struct {
+	ID          string
+	Description string
+	check       func(c trackContext) []Violation
+}
+
+// Violation is one rule failure, located in a track file.
+type Violation struct {
+	Rule     string
+	Severity Severity
+	TrackID  string
+	Path     string // file path relative to the project root, slash-separated
+	Line     int    // 1-based; 0 when the violation has no specific line
+	Message  string
+}
+
+// Rules lists every rule in reporting order.
+var Rules = []Rule{
+	{
+		ID:          "completed-unchecked",
+		Description: "Track marked completed in metadata.json still has unchecked tasks",
+		check:       checkCompletedUnchecked,
+	},
+	{
+		ID:          "in-progress-no-plan",
+		Description: "Track marked in_progress has no plan.md",
+		check:       checkInProgressNoPlan,
+	},
+	{
+		ID:          "task-missing-commit",
+		Description: "Task marked [x] has no commit SHA",
+		check:       checkTaskMissingCommit,
+	},
+	{
+		ID:          "phase-missing-checkpoint",
+		Description: "Phase with all tasks done has no [checkpoint: ...]",
+		check:       checkPhaseMissingCheckpoint,
+	},
+}
+
+// Config sets the severity of each rule. Rules not listed default to
+// SeverityError.
+type Config struct {
+	Severity map[string]Severity
+}
+
+// SeverityOf returns the configured severity for the rule.
+func (c Config) SeverityOf(rule string) Severity {
+	if s, ok := c.Severity[rule]; ok {
+		return s
+	}
+	return SeverityError
+}
+
+// Set applies severity to each comma-separated rule ID in list, returning
+// an error for unknown IDs.
+func (c *Config) Set(list string, severity Severity) error {
+	for _, id := range strings.Split(list, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := FindRule(id); !ok {
+			return fmt.Errorf("unknown rule %q", id)
+		}
+		if c.Severity == nil {
+			c.Severity = map[string]Severity{}
+		}
+		c.Severity[id] = severity
+	}
+	return nil
+}
+
+// FindRule returns the rule with the given ID.
+func FindRule(id string) (Rule, bool) {
+	for _, r := range Rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Rule{}, false
+}
+
+// trackContext is what a rule sees for one track.
+type trackContext struct {
+	track    conductor.Track
+	metaPath string // relative to the project root
+	planPath string // relative to the project root
+	hasPlan  bool
+}
+
+// Run applies every enabled rule to tracks and returns the violations in
+// track order, then rule order.
+func Run(basePath string, tracks []conductor.Track, cfg Config) []Violation {
+	var out []Violation
+	p := conductor.New(basePath)
+	for _, t := range tracks {
+		dir := p.Dir(t)
+		c := trackContext{
+			track:    t,
+			metaPath: relPath(basePath, filepath.Join(dir, "metadata.json")),
+			planPath: relPath(basePath, filepath.Join(dir, "plan.md")),
+		}
+		if _, err := os.Stat(filepath.Join(dir, "plan.md")); err == nil {
+			c.hasPlan = true
+		}
+		for _, r := range Rules {
+			sev := cfg.SeverityOf(r.ID)
+			if sev == SeverityOff {
+				continue
+			}
+			for _, v := range r.check(c) {
+				v.Rule = r.ID
+				v.Severity = sev
+				v.TrackID = t.TrackID
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+// Failed reports whether any violation has error severity.
+func Failed(violations []Violation) bool {
+	for _, v := range violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func relPath(basePath, path string) string {
+	if rel, err := filepath.Rel(basePath, path); err == nil {
+		path = rel
+	}
+	return filepath.ToSlash(path)
+}
+
+func checkCompletedUnchecked(c trackContext) []Violation {
+	if c.track.Status != "completed" {
+		return nil
+	}
+	var out []Violation
+	for _, p := range c.track.Phases {
+		for _, t := range p.Tasks {
+			if !t.Completed {
+				out = append(out, Violation{
+					Path:    c.planPath,
+					Line:    t.Line,
+					Message: fmt.Sprintf("track is completed but task %q in phase %d is not checked", t.Name, p.Number),
+				})
+			}
+		}
+	}
+	return out
+}
+
+func checkInProgressNoPlan(c trackContext) []Violation {
+	if c.track.Status != "in_progress" || c.hasPlan {
+		return nil
+	}
+	return []Violation{{
+		Path:    c.metaPath,
+		Message: "track is in_progress but has no plan.md",
+	}}
+}
+
+func checkTaskMissingCommit(c trackContext) []Violation {
+	var out []Violation
+	for _, p := range c.track.Phases {
+		for _, t := range p.Tasks {
+			if t.Completed && t.Commit == "" {
+				out = append(out, Violation{
+					Path:    c.planPath,
+					Line:    t.Line,
+					Message: fmt.Sprintf("task %q is marked [x] without a commit SHA", t.Name),
+				})
+			}
+		}
+	}
+	return out
+}
+
+func checkPhaseMissingCheckpoint(c trackContext) []Violation {
+	var out []Violation
+	for _, p := range c.track.Phases {
+		if len(p.Tasks) == 0 || p.Checkpoint != "" {
+			continue
+		}
+		done := true
+		for _, t := range p.Tasks {
+			if !t.Completed {
+				done = false
+				break
+			}
+		}
+		if done {
+			out = append(out, Violation{
+				Path:    c.planPath,
+				Line:    p.Line,
+				Message: fmt.Sprintf("phase %d %q has all tasks done but no [checkpoint: ...]", p.Number, p.Name),
+			})
+		}
+	}
+	return out
+}
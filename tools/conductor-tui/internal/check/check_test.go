@@ -0,0 +1,137 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// writeTrack creates an active track with the given status and plan. An
+// empty plan leaves plan.md out.
+func writeTrack(t *testing.T, base, id, status, plan string) {
+	t.Helper()
+	dir := filepath.Join(base, "conductor", "tracks", id)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"track_id": "` + id + `", "type": "feature", "status": "` + status + `"}`
+	if err := os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if plan != "" {
+		if err := os.WriteFile(filepath.Join(dir, "plan.md"), []byte(plan), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func fixture(t *testing.T) (string, []conductor.Track) {
+	t.Helper()
+	base := t.TempDir()
+	writeTrack(t, base, "done_20260101", "completed",
+		"## Phase 1: Build\n\n- [x] Task: One `abc1234`\n- [ ] Task: Two\n")
+	writeTrack(t, base, "empty_20260102", "in_progress", "")
+	writeTrack(t, base, "nosha_20260103", "in_progress",
+		"## Phase 1: Build\n\n- [x] Task: One\n- [x] Task: Two `abc1234`\n\n## Phase 2: Ship [checkpoint: def5678]\n\n- [x] Task: Three `def5678`\n")
+	return base, conductor.DiscoverTracks(base)
+}
+
+func rules(violations []Violation) []string {
+	var out []string
+	for _, v := range violations {
+		out = append(out, v.TrackID+"/"+v.Rule)
+	}
+	return out
+}
+
+func TestRun_AllRules(t *testing.T) {
+	base, tracks := fixture(t)
+	violations := Run(base, tracks, Config{})
+
+	// Tracks without created_at sort alphabetically.
+	want := []string{
+		"done_20260101/completed-unchecked",
+		"empty_20260102/in-progress-no-plan",
+		"nosha_20260103/task-missing-commit",
+		"nosha_20260103/phase-missing-checkpoint",
+	}
+	if got := rules(violations); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("violations = %v, want %v", got, want)
+	}
+	v := violations[2]
+	if v.Path != "conductor/tracks/nosha_20260103/plan.md" || v.Line != 3 {
+		t.Errorf("location = %s:%d, want plan.md:3", v.Path, v.Line)
+	}
+	if violations[3].Line != 1 {
+		t.Errorf("checkpoint violation should point at the phase heading, got line %d", violations[3].Line)
+	}
+	if violations[1].Path != "conductor/tracks/empty_20260102/metadata.json" {
+		t.Errorf("no-plan violation path = %q", violations[1].Path)
+	}
+	if !Failed(violations) {
+		t.Error("error violations should fail")
+	}
+}
+
+func TestRun_ConfiguredSeverity(t *testing.T) {
+	base, tracks := fixture(t)
+	var cfg Config
+	if err := cfg.Set("task-missing-commit, phase-missing-checkpoint", SeverityOff); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Set("completed-unchecked,in-progress-no-plan", SeverityWarning); err != nil {
+		t.Fatal(err)
+	}
+	violations := Run(base, tracks, cfg)
+	if len(violations) != 2 {
+		t.Fatalf("got %v, want 2 warnings", rules(violations))
+	}
+	if Failed(violations) {
+		t.Error("warnings alone should not fail")
+	}
+	if err := cfg.Set("no-such-rule", SeverityOff); err == nil {
+		t.Error("expected error for unknown rule")
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	base, tracks := fixture(t)
+	violations := Run(base, tracks, Config{})
+	var buf bytes.Buffer
+	if err := WriteJUnit(&buf, []string{"done_20260101", "empty_20260102", "nosha_20260103"}, violations, Config{}); err != nil {
+		t.Fatal(err)
+	}
+	var report junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+	if report.Tests != 12 || report.Failures != 4 {
+		t.Errorf("tests = %d, failures = %d; want 12, 4", report.Tests, report.Failures)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	base, tracks := fixture(t)
+	violations := Run(base, tracks, Config{})
+	var buf bytes.Buffer
+	if err := WriteSARIF(&buf, violations, "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 4 {
+		t.Fatalf("unexpected SARIF log: %s", buf.String())
+	}
+	r := log.Runs[0].Results[2]
+	if r.RuleID != "task-missing-commit" || r.Level != "error" || r.Locations[0].PhysicalLocation.Region.StartLine != 3 {
+		t.Errorf("result = %+v", r)
+	}
+}
@@ -0,0 +1,209 @@
+package check
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText writes one line per violation in file:line: form, the format
+// most CI log parsers pick up.
+func WriteText(w io.Writer, violations []Violation) error {
+	for _, v := range violations {
+		loc := v.Path
+		if v.Line > 0 {
+			loc = fmt.Sprintf("%s:%d", v.Path, v.Line)
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s: %s [%s]\n", loc, v.Severity, v.Message, v.Rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes a JUnit XML report with one test suite per track and
+// one test case per enabled rule. Error violations become failures;
+// warnings are attached as system-out so they do not fail the build.
+func WriteJUnit(w io.Writer, trackIDs []string, violations []Violation, cfg Config) error {
+	byTrack := map[string][]Violation{}
+	for _, v := range violations {
+		byTrack[v.TrackID] = append(byTrack[v.TrackID], v)
+	}
+
+	report := junitSuites{Name: "conductor-check"}
+	for _, id := range trackIDs {
+		suite := junitSuite{Name: id}
+		for _, r := range Rules {
+			if cfg.SeverityOf(r.ID) == SeverityOff {
+				continue
+			}
+			tc := junitCase{Name: r.ID, Classname: "conductor." + id}
+			var errs, warns []Violation
+			for _, v := range byTrack[id] {
+				if v.Rule != r.ID {
+					continue
+				}
+				if v.Severity == SeverityError {
+					errs = append(errs, v)
+				} else {
+					warns = append(warns, v)
+				}
+			}
+			if len(errs) > 0 {
+				tc.File, tc.Line = errs[0].Path, errs[0].Line
+				tc.Failure = &junitFailure{Message: errs[0].Message, Type: r.ID, Text: violationLines(errs)}
+				suite.Failures++
+			}
+			if len(warns) > 0 {
+				tc.SystemOut = violationLines(warns)
+			}
+			suite.Tests++
+			suite.Cases = append(suite.Cases, tc)
+		}
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func violationLines(violations []Violation) string {
+	var b strings.Builder
+	for _, v := range violations {
+		if v.Line > 0 {
+			fmt.Fprintf(&b, "%s:%d: %s\n", v.Path, v.Line, v.Message)
+		} else {
+			fmt.Fprintf(&b, "%s: %s\n", v.Path, v.Message)
+		}
+	}
+	return b.String()
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysical `json:"physicalLocation"`
+}
+
+type sarifPhysical struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+	Region           *sarifRegion  `json:"region,omitempty"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// WriteSARIF writes a SARIF 2.1.0 log, the format GitHub code scanning
+// and most CI systems turn into inline annotations.
+func WriteSARIF(w io.Writer, violations []Violation, version string) error {
+	run := sarifRun{
+		Tool:    sarifTool{Driver: sarifDriver{Name: "conductor-tui", Version: version}},
+		Results: []sarifResult{},
+	}
+	for _, r := range Rules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules,
+			sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}})
+	}
+	for _, v := range violations {
+		loc := sarifPhysical{ArtifactLocation: sarifArtifact{URI: v.Path}}
+		if v.Line > 0 {
+			loc.Region = &sarifRegion{StartLine: v.Line}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    v.Rule,
+			Level:     string(v.Severity),
+			Message:   sarifMessage{Text: v.Message},
+			Locations: []sarifLocation{{PhysicalLocation: loc}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/check"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Check implements `conductor-tui check`, a CI gate that validates tracks
+// against the completion policies in package check. It returns 1 when any
+// error-severity rule is violated, 2 on usage errors and 0 otherwise.
+func Check(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "text", "output format: text, junit or sarif")
+	disable := fs.String("disable", "", "comma-separated rules to skip")
+	warn := fs.String("warn", "", "comma-separated rules to report without failing")
+	archived := fs.Bool("archived", false, "also check archived tracks")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui check [--disable r1,r2] [--warn r1,r2] [--archived] [--format text|junit|sarif] [track_id...]")
+		fs.PrintDefaults()
+		fmt.Fprintln(stderr, "\nRules:")
+		for _, r := range check.Rules {
+			fmt.Fprintf(stderr, "  %-26s %s\n", r.ID, r.Description)
+		}
+	}
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+
+	var cfg check.Config
+	if err := cfg.Set(*warn, check.SeverityWarning); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	if err := cfg.Set(*disable, check.SeverityOff); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	var tracks []conductor.Track
+	for _, t := range conductor.DiscoverTracks(basePath) {
+		if len(ids) > 0 {
+			if slices.Contains(ids, t.TrackID) {
+				tracks = append(tracks, t)
+			}
+		} else if *archived || t.Source != "archived" {
+			tracks = append(tracks, t)
+		}
+	}
+	for _, id := range ids {
+		if !containsTrack(tracks, id) {
+			fmt.Fprintf(stderr, "Error: track %q not found\n", id)
+			return 2
+		}
+	}
+
+	violations := check.Run(basePath, tracks, cfg)
+	switch *format {
+	case "text":
+		err = check.WriteText(stdout, violations)
+		if err == nil {
+			fmt.Fprintf(stdout, "%d tracks checked, %d violations\n", len(tracks), len(violations))
+		}
+	case "junit":
+		trackIDs := make([]string, len(tracks))
+		for i, t := range tracks {
+			trackIDs[i] = t.TrackID
+		}
+		err = check.WriteJUnit(stdout, trackIDs, violations, cfg)
+	case "sarif":
+		err = check.WriteSARIF(stdout, violations, Version)
+	default:
+		fmt.Fprintf(stderr, "Error: unknown format %q (want text, junit or sarif)\n", *format)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	if check.Failed(violations) {
+		return 1
+	}
+	return 0
+}
+
+func containsTrack(tracks []conductor.Track, id string) bool {
+	for _, t := range tracks {
+		if t.TrackID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+// Package cli implements the headless conductor-tui subcommands.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Version is reported in machine-readable output; main sets it from the
+// TUI build version.
+var Version = "dev"
+
+// command is a subcommand entry point. It returns the process exit code.
+type command func(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int
+
+// commands maps subcommand names to their implementations.
+var commands = map[string]command{
+	"check":   Check,
+	"export":  Export,
+	"list":    List,
+	"lsp":     LSP,
+	"mcp":     MCP,
+	"metrics": Metrics,
+	"show":    Show,
+	"status":  Status,
+	"revert":  Revert,
+	"serve":   Serve,
+	"watch":   Watch,
+}
+
+// Run dispatches args[0] to its subcommand and returns the exit code.
+func Run(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" {
+		usage(stdout)
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	return cmd(basePath, args[1:], stdin, stdout, stderr)
+}
+
+// usage prints the list of subcommands.
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "Usage: conductor-tui [command] [flags]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Without a command, starts the interactive TUI.")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Run 'conductor-tui <command> -h' for command flags.")
+}
+
+// parseArgs parses flags that may appear before or after positional
+// arguments and returns the positional arguments in order.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// findTrack discovers tracks under basePath and returns the one with the
+// given ID along with its directory.
+func findTrack(basePath, trackID string) (conductor.Track, string, error) {
+	p := conductor.New(basePath)
+	t, err := p.Track(trackID)
+	if err != nil {
+		return conductor.Track{}, "", err
+	}
+	return t, p.Dir(t), nil
+}
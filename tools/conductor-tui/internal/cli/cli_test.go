@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+const discoveryPath = "../../testdata/discovery"
+
+func TestParseArgs_FlagsAfterPositional(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	phase := fs.Int("phase", 0, "")
+	positional, err := parseArgs(fs, []string{"track_1", "--phase", "2", "extra"})
+	if err != nil {
+		t.Fatalf("parseArgs returned error: %v", err)
+	}
+	if strings.Join(positional, ",") != "track_1,extra" {
+		t.Errorf("positional = %v, want [track_1 extra]", positional)
+	}
+	if *phase != 2 {
+		t.Errorf("phase = %d, want 2", *phase)
+	}
+}
+
+func TestFindTrack(t *testing.T) {
+	track, dir, err := findTrack(discoveryPath, "feature-gamma_20250601")
+	if err != nil {
+		t.Fatalf("findTrack returned error: %v", err)
+	}
+	if track.Source != "archived" {
+		t.Errorf("Source = %q, want archived", track.Source)
+	}
+	if !strings.HasSuffix(dir, "conductor/archive/feature-gamma_20250601") {
+		t.Errorf("dir = %q, want archive path", dir)
+	}
+	if _, _, err := findTrack(discoveryPath, "missing"); err == nil {
+		t.Error("expected error for unknown track")
+	}
+}
+
+func TestRevert_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Revert(discoveryPath, nil, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2 without a track ID", code)
+	}
+	if !strings.Contains(stderr.String(), "Usage: conductor-tui revert") {
+		t.Error("usage should be printed")
+	}
+}
+
+func TestRevert_TaskRequiresPhase(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Revert(discoveryPath, []string{"feature-alpha_20260101", "--task", "1"}, nil, &stdout, &stderr)
+	if code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+}
+
+func TestRevert_UnknownTrack(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Revert(discoveryPath, []string{"missing"}, nil, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), `track "missing" not found`) {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestRevert_UnknownPhase(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Revert(discoveryPath, []string{"feature-alpha_20260101", "--phase", "9"}, nil, &stdout, &stderr)
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "phase 9 not found") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run(discoveryPath, []string{"bogus"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+	if !strings.Contains(stderr.String(), "Commands:") {
+		t.Error("usage should be printed for an unknown command")
+	}
+}
+
+func TestList_FiltersAndJSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := List(discoveryPath, []string{"--format", "json", "--status", "in_progress,completed", "--archived"}, nil, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+	var got []trackSummary
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(got) != 2 || got[0].TrackID != "feature-alpha_20260101" || got[1].TrackID != "feature-gamma_20250601" {
+		t.Fatalf("got %+v, want alpha and gamma", got)
+	}
+	if got[0].Progress.Done != 1 || got[0].Progress.Total != 2 {
+		t.Errorf("alpha progress = %+v, want 1/2", got[0].Progress)
+	}
+}
+
+func TestList_TableHidesArchived(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := List(discoveryPath, []string{"--type", "bug"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "bugfix-beta_20260102") || strings.Contains(out, "feature-") {
+		t.Errorf("table should list only the bug track:\n%s", out)
+	}
+}
+
+func TestList_UnknownFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := List(discoveryPath, []string{"--format", "xml"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+}
+
+func TestShow_YAML(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Show(discoveryPath, []string{"feature-alpha_20260101", "--format", "yaml"}, nil, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"track_id: feature-alpha_20260101\n",
+		"  - number: 1\n",
+		"        commit: abc1234\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("YAML missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "sub_tasks") {
+		t.Error("tasks without sub-tasks should omit sub_tasks")
+	}
+}
+
+func TestShow_Table(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Show(discoveryPath, []string{"feature-alpha_20260101"}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d", code)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "Progress: 1/2 tasks (50%)") || !strings.Contains(out, "[ ] Add dependencies") {
+		t.Errorf("unexpected table output:\n%s", out)
+	}
+}
+
+func TestBuildStatus(t *testing.T) {
+	tracks := conductor.DiscoverTracks(discoveryPath)
+	tracks = append(tracks, conductor.Track{TrackID: "stuck", Status: "blocked", Source: "active"})
+	r := buildStatus(tracks, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	if r.GeneratedAt != "2026-03-01T12:00:00Z" {
+		t.Errorf("GeneratedAt = %q", r.GeneratedAt)
+	}
+	if r.ProjectStatus != "Blocked" || len(r.Blockers) != 1 || r.Blockers[0] != "stuck" {
+		t.Errorf("status = %q, blockers = %v", r.ProjectStatus, r.Blockers)
+	}
+	if r.Tracks != 3 || r.Phases != 1 {
+		t.Errorf("tracks = %d, phases = %d; want 3, 1 (archived excluded)", r.Tracks, r.Phases)
+	}
+	if r.Progress.Done != 1 || r.Progress.Total != 2 || r.Percent != 50 {
+		t.Errorf("progress = %+v (%d%%)", r.Progress, r.Percent)
+	}
+	if len(r.Next) != 1 || r.Next[0].Task != "Add dependencies" {
+		t.Errorf("next = %+v", r.Next)
+	}
+}
+
+func TestMarshalYAML_Quoting(t *testing.T) {
+	out, err := marshalYAML(map[string]any{"a": "yes", "b": "x: y", "c": "plain", "d": []string{}, "e": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "a: \"yes\"\nb: \"x: y\"\nc: plain\nd: []\ne: 3\n"
+	if string(out) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestCheck_ExitCodes(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	// Alpha's completed task has a SHA; nothing else is done.
+	if code := Check(discoveryPath, nil, nil, &stdout, &stderr); code != 0 {
+		t.Errorf("exit code = %d, want 0:\n%s", code, stdout.String())
+	}
+	if code := Check(discoveryPath, []string{"--disable", "bogus"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2 for unknown rule", code)
+	}
+	if code := Check(discoveryPath, []string{"missing"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2 for unknown track", code)
+	}
+}
+
+func TestExport_CSVFiltered(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := Export(discoveryPath, []string{"--format", "csv", "--type", "feature", "--archived"}, nil, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "feature-alpha_20260101,") || !strings.HasPrefix(lines[2], "feature-gamma_20250601,") {
+		t.Errorf("unexpected CSV:\n%s", stdout.String())
+	}
+}
+
+func TestExport_TemplateErrorLeavesNoFile(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := filepath.Join(dir, "bad.tmpl")
+	if err := os.WriteFile(tmpl, []byte("{{range .Tracks}}{{.NoSuchField}}{{end}}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "report.md")
+	var stdout, stderr bytes.Buffer
+	if code := Export(discoveryPath, []string{"--template", tmpl, "-o", out}, nil, &stdout, &stderr); code != 1 {
+		t.Fatalf("exit code = %d, want 1", code)
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Errorf("a failed export should not create %s", out)
+	}
+}
+
+func TestMetrics_WritesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conductor.prom")
+	var stdout, stderr bytes.Buffer
+	if code := Metrics(discoveryPath, []string{"-o", path}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d, stderr = %s", code, stderr.String())
+	}
+	out, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "conductor_tasks 2\n") {
+		t.Errorf("unexpected metrics:\n%s", out)
+	}
+	if stdout.Len() != 0 {
+		t.Error("nothing should be written to stdout with -o")
+	}
+}
+
+func TestWatch_RejectsBadInterval(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := watch(context.Background(), discoveryPath, []string{"--interval", "0s"}, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+}
+
+func TestServe_RejectsBadInterval(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Serve(discoveryPath, []string{"--interval", "0s"}, nil, &stdout, &stderr); code != 2 {
+		t.Errorf("exit code = %d, want 2", code)
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	e := events.Event{Type: events.TaskCompleted, TrackID: "alpha", Phase: 2, Task: "Ship it", Commit: "abc1234"}
+	if got := formatEvent(e); !strings.HasSuffix(got, " alpha > Phase 2 > Ship it completed (abc1234)") {
+		t.Errorf("formatEvent = %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/export"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Export implements `conductor-tui export`: a Markdown, HTML or CSV
+// status report for the selected tracks. Without --template, a project
+// template at conductor/templates/export.<ext>.tmpl overrides the built-in
+// one when present.
+func Export(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "markdown", "output format: markdown, html or csv")
+	level := fs.String("level", "track", "CSV row level: track, phase or task")
+	tmplPath := fs.String("template", "", "text/template file overriding the built-in Markdown or HTML template")
+	output := fs.String("o", "", "write to file instead of stdout")
+	status := fs.String("status", "", "comma-separated statuses to include")
+	typ := fs.String("type", "", "comma-separated types to include")
+	archived := fs.Bool("archived", false, "include archived tracks")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui export [--format markdown|html|csv] [--level track|phase|task] [--template file] [-o file] [filters] [track_id...]")
+		fs.PrintDefaults()
+	}
+	ids, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+
+	statuses, types := util.SplitList(*status), util.SplitList(*typ)
+	var tracks []conductor.Track
+	for _, t := range conductor.DiscoverTracks(basePath) {
+		switch {
+		case len(ids) > 0 && !slices.Contains(ids, t.TrackID),
+			len(ids) == 0 && !*archived && t.Source == "archived",
+			len(statuses) > 0 && !slices.Contains(statuses, t.Status),
+			len(types) > 0 && !slices.Contains(types, t.Type):
+			continue
+		}
+		tracks = append(tracks, t)
+	}
+
+	opts := export.Options{Format: *format, Level: *level, Template: *tmplPath}
+	if opts.Template == "" {
+		if p := export.TemplatePath(basePath, opts.Format); fileExists(p) {
+			opts.Template = p
+		}
+	}
+
+	// Render in full first, so that a template error leaves no partial file.
+	var buf bytes.Buffer
+	if err := export.Write(&buf, export.Build(tracks, time.Now()), opts); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *output == "" {
+		stdout.Write(buf.Bytes())
+		return 0
+	}
+	if err := conductor.WriteFileAtomic(*output, buf.Bytes()); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
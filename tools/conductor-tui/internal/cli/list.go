@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// trackSummary is the list output for one track: its metadata plus task
+// progress, without the full plan.
+type trackSummary struct {
+	TrackID     string             `json:"track_id"`
+	Type        string             `json:"type"`
+	Status      string             `json:"status"`
+	Description string             `json:"description"`
+	Source      string             `json:"source"`
+	CreatedAt   string             `json:"created_at,omitempty"`
+	UpdatedAt   string             `json:"updated_at,omitempty"`
+	Phases      int                `json:"phases"`
+	Progress    conductor.Progress `json:"progress"`
+}
+
+func summarize(t conductor.Track) trackSummary {
+	s := trackSummary{
+		TrackID:     t.TrackID,
+		Type:        t.Type,
+		Status:      t.Status,
+		Description: t.Description,
+		Source:      t.Source,
+		Phases:      len(t.Phases),
+		Progress:    conductor.TrackProgress(t),
+	}
+	if !t.CreatedAt.IsZero() {
+		s.CreatedAt = t.CreatedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	if !t.UpdatedAt.IsZero() {
+		s.UpdatedAt = t.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return s
+}
+
+// List implements `conductor-tui list`: tracks with optional filters.
+func List(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "table", "output format: table, json or yaml")
+	status := fs.String("status", "", "comma-separated statuses to include")
+	typ := fs.String("type", "", "comma-separated types to include")
+	archived := fs.Bool("archived", false, "include archived tracks")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui list [--status s1,s2] [--type t1,t2] [--archived] [--format table|json|yaml]")
+		fs.PrintDefaults()
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		return 2
+	}
+
+	statuses, types := util.SplitList(*status), util.SplitList(*typ)
+	summaries := []trackSummary{}
+	for _, t := range conductor.New(basePath).Tracks(conductor.Filter{Statuses: statuses, Types: types, Archived: *archived}) {
+		summaries = append(summaries, summarize(t))
+	}
+
+	err := writeFormatted(stdout, *format, summaries, func(w io.Writer) {
+		if len(summaries) == 0 {
+			fmt.Fprintln(w, "No tracks found.")
+			return
+		}
+		fmt.Fprintln(w, util.Pad("TRACK ID", 30)+util.Pad("TYPE", 10)+util.Pad("STATUS", 14)+util.Pad("TASKS", 10)+"DESCRIPTION")
+		for _, s := range summaries {
+			status := s.Status
+			if s.Source == "archived" {
+				status += " *"
+			}
+			fmt.Fprintln(w, util.Pad(s.TrackID, 29)+" "+
+				util.Pad(s.Type, 10)+
+				util.Pad(status, 14)+
+				util.Pad(fmt.Sprintf("%d/%d", s.Progress.Done, s.Progress.Total), 10)+
+				s.Description)
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
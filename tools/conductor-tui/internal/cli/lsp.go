@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/lsp"
+)
+
+// LSP implements `conductor-tui lsp`: a language server for plan.md and
+// metadata.json on stdin and stdout.
+func LSP(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lsp", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	// Editors such as VS Code pass --stdio; it is the only transport.
+	fs.Bool("stdio", true, "communicate over stdin and stdout (the default)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui lsp [--stdio]")
+		fmt.Fprintln(stderr, "Serves the Language Server Protocol over stdio; configure it in your editor for plan.md and metadata.json.")
+		fs.PrintDefaults()
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := lsp.New(Version).Serve(ctx, stdin, stdout); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/mcp"
+)
+
+// MCP implements `conductor-tui mcp`: a Model Context Protocol server on
+// stdin and stdout that lets AI agents query and update tracks.
+func MCP(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui mcp")
+		fmt.Fprintln(stderr, "Serves the Model Context Protocol over stdio; configure it as a command in your agent.")
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if err := mcp.New(basePath, Version).Serve(ctx, stdin, stdout); err != nil && ctx.Err() == nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
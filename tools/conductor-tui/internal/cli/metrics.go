@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/metrics"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Metrics implements `conductor-tui metrics`: track progress in the
+// Prometheus text format. With -o the file is replaced atomically, as the
+// node_exporter textfile collector requires.
+func Metrics(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("metrics", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", "", "write to file (e.g. /var/lib/node_exporter/conductor.prom) instead of stdout")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui metrics [-o file.prom]")
+		fs.PrintDefaults()
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		return 2
+	}
+
+	var buf bytes.Buffer
+	if err := metrics.Write(&buf, conductor.DiscoverTracks(basePath), time.Now()); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *output == "" {
+		stdout.Write(buf.Bytes())
+		return 0
+	}
+	if err := conductor.WriteFileAtomic(*output, buf.Bytes()); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/revert"
+)
+
+// Revert implements `conductor-tui revert <track_id> [--phase N [--task M]]`.
+// It prints the revert plan and only runs git revert with --execute, after
+// an interactive confirmation unless --yes is given. It returns the
+// process exit code.
+func Revert(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("revert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	phase := fs.Int("phase", 0, "phase number to revert (default: whole track)")
+	task := fs.Int("task", 0, "task number within --phase to revert (1-based)")
+	execute := fs.Bool("execute", false, "run git revert instead of printing the plan")
+	yes := fs.Bool("yes", false, "skip the confirmation prompt with --execute")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui revert <track_id> [--phase N [--task M]] [--execute [--yes]]")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return 2
+	}
+	if *task > 0 && *phase == 0 {
+		fmt.Fprintln(stderr, "Error: --task requires --phase")
+		return 2
+	}
+
+	track, dir, err := findTrack(basePath, positional[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	target := revert.Target{Track: track, Dir: dir, PhaseIdx: -1, TaskIdx: -1}
+	if *phase > 0 {
+		for i, p := range track.Phases {
+			if p.Number == *phase {
+				target.PhaseIdx = i
+			}
+		}
+		if target.PhaseIdx < 0 {
+			fmt.Fprintf(stderr, "Error: phase %d not found in %s\n", *phase, track.TrackID)
+			return 1
+		}
+		if *task > 0 {
+			if *task > len(track.Phases[target.PhaseIdx].Tasks) {
+				fmt.Fprintf(stderr, "Error: task %d not found in phase %d\n", *task, *phase)
+				return 1
+			}
+			target.TaskIdx = *task - 1
+		}
+	}
+
+	plan, err := revert.Build(target)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprint(stdout, revert.Format(plan))
+
+	if len(plan.Commits) == 0 {
+		fmt.Fprintln(stdout, "Nothing to revert.")
+		return 0
+	}
+	if !*execute {
+		fmt.Fprintln(stdout, "Dry run: re-run with --execute to revert these commits.")
+		return 0
+	}
+	if !*yes {
+		fmt.Fprint(stdout, "Do you want to proceed? [y/N] ")
+		answer, _ := bufio.NewReader(stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Fprintln(stdout, "Aborted.")
+			return 1
+		}
+	}
+	if err := revert.Execute(dir, plan); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Reverted %d commits.\n", len(plan.Commits))
+	return 0
+}
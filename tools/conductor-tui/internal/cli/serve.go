@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/metrics"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/server"
+)
+
+// Serve implements `conductor-tui serve`: a read-only JSON API and HTML
+// dashboard over the discovered tracks. It runs until the process exits.
+func Serve(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	withMetrics := fs.Bool("metrics", false, "also expose Prometheus metrics at /metrics")
+	interval := fs.Duration("interval", 2*time.Second, "how often live updates re-scan the tracks")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui serve [--addr :8080] [--interval 2s] [--metrics]")
+		fs.PrintDefaults()
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		return 2
+	}
+	if *interval <= 0 {
+		fmt.Fprintln(stderr, "Error: --interval must be positive")
+		return 2
+	}
+
+	srv := server.New(basePath)
+	srv.Interval = *interval
+	if *withMetrics {
+		srv.Handle("GET /metrics", metrics.Handler(basePath))
+	}
+
+	fmt.Fprintf(stdout, "Serving Conductor dashboard on http://%s/\n", displayAddr(*addr))
+	if err := http.ListenAndServe(*addr, srv); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// displayAddr turns a listen address such as ":8080" into a browsable
+// host:port.
+func displayAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
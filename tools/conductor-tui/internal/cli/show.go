@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// trackDetail is the show output: the full track with its progress.
+type trackDetail struct {
+	conductor.Track
+	Progress conductor.Progress `json:"progress"`
+}
+
+// checkbox renders a task's plan.md marker.
+func checkbox(t conductor.Task) string {
+	switch {
+	case t.Completed:
+		return "[x]"
+	case t.InProgress:
+		return "[~]"
+	default:
+		return "[ ]"
+	}
+}
+
+// Show implements `conductor-tui show <track_id>`: phases and tasks.
+func Show(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "table", "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui show <track_id> [--format table|json|yaml]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	track, _, err := findTrack(basePath, positional[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if track.Phases == nil {
+		track.Phases = []conductor.Phase{}
+	}
+	detail := trackDetail{Track: track, Progress: conductor.TrackProgress(track)}
+
+	err = writeFormatted(stdout, *format, detail, func(w io.Writer) {
+		fmt.Fprintf(w, "%s  (%s, %s)\n", track.TrackID, track.Type, track.Status)
+		if track.Description != "" {
+			fmt.Fprintln(w, util.Wrap(track.Description, 78, ""))
+		}
+		p := detail.Progress
+		fmt.Fprintf(w, "Progress: %d/%d tasks (%d%%)\n", p.Done, p.Total, p.Percent())
+		if len(track.Phases) == 0 {
+			fmt.Fprintln(w, "\nNo plan.")
+			return
+		}
+		for _, ph := range track.Phases {
+			fmt.Fprintf(w, "\nPhase %d: %s  [%s]", ph.Number, ph.Name, conductor.PhaseStatus(ph))
+			if ph.Checkpoint != "" {
+				fmt.Fprintf(w, "  checkpoint %s", ph.Checkpoint)
+			}
+			fmt.Fprintln(w)
+			for _, t := range ph.Tasks {
+				line := "  " + checkbox(t) + " " + t.Name
+				if t.Commit != "" {
+					line += "  " + t.Commit
+				}
+				fmt.Fprintln(w, line)
+				for _, sub := range t.SubTasks {
+					mark := "[ ]"
+					if sub.Completed {
+						mark = "[x]"
+					}
+					fmt.Fprintln(w, "      "+mark+" "+sub.Name)
+				}
+			}
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
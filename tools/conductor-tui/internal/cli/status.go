@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// taskRef points at a task within a track's plan.
+type taskRef struct {
+	TrackID string `json:"track_id"`
+	Phase   int    `json:"phase"`
+	Name    string `json:"phase_name"`
+	Task    string `json:"task"`
+}
+
+// statusReport mirrors the /conductor:status skill's overview.
+type statusReport struct {
+	GeneratedAt   string             `json:"generated_at"`
+	ProjectStatus string             `json:"project_status"`
+	Current       []taskRef          `json:"current"`
+	Next          []taskRef          `json:"next"`
+	Blockers      []string           `json:"blockers"`
+	Tracks        int                `json:"tracks"`
+	Phases        int                `json:"phases"`
+	Progress      conductor.Progress `json:"progress"`
+	Percent       int                `json:"percent"`
+	TrackSummary  []trackSummary     `json:"track_summary"`
+}
+
+// buildStatus computes the status overview across active tracks.
+func buildStatus(tracks []conductor.Track, now time.Time) statusReport {
+	r := statusReport{
+		GeneratedAt:  now.UTC().Format(time.RFC3339),
+		Current:      []taskRef{},
+		Next:         []taskRef{},
+		Blockers:     []string{},
+		TrackSummary: []trackSummary{},
+	}
+	for _, t := range tracks {
+		if t.Source == "archived" {
+			continue
+		}
+		r.Tracks++
+		r.Phases += len(t.Phases)
+		r.TrackSummary = append(r.TrackSummary, summarize(t))
+		if t.Status == "blocked" {
+			r.Blockers = append(r.Blockers, t.TrackID)
+		}
+
+		p := conductor.TrackProgress(t)
+		r.Progress.Total += p.Total
+		r.Progress.Done += p.Done
+		r.Progress.InProgress += p.InProgress
+		r.Progress.Pending += p.Pending
+
+		nextFound := false
+		for _, ph := range t.Phases {
+			for _, task := range ph.Tasks {
+				ref := taskRef{TrackID: t.TrackID, Phase: ph.Number, Name: ph.Name, Task: task.Name}
+				switch {
+				case task.InProgress:
+					r.Current = append(r.Current, ref)
+				case !task.Completed && !nextFound && t.Status != "cancelled":
+					r.Next = append(r.Next, ref)
+					nextFound = true
+				}
+			}
+		}
+	}
+	r.Percent = r.Progress.Percent()
+
+	switch {
+	case len(r.Blockers) > 0:
+		r.ProjectStatus = "Blocked"
+	case r.Progress.Total > 0 && r.Progress.Done == r.Progress.Total:
+		r.ProjectStatus = "Completed"
+	case r.Progress.Done > 0 || r.Progress.InProgress > 0:
+		r.ProjectStatus = "In Progress"
+	default:
+		r.ProjectStatus = "Not Started"
+	}
+	return r
+}
+
+// Status implements `conductor-tui status`, the headless equivalent of
+// the /conductor:status skill.
+func Status(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "table", "output format: table, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui status [--format table|json|yaml]")
+		fs.PrintDefaults()
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		return 2
+	}
+
+	r := buildStatus(conductor.DiscoverTracks(basePath), time.Now())
+	err := writeFormatted(stdout, *format, r, func(w io.Writer) {
+		fmt.Fprintf(w, "Current Date/Time: %s\n", r.GeneratedAt)
+		fmt.Fprintf(w, "Project Status:    %s\n", r.ProjectStatus)
+		writeRefs(w, "Current Task:      ", r.Current, "none in progress")
+		writeRefs(w, "Next Action:       ", r.Next, "no pending tasks")
+		if len(r.Blockers) == 0 {
+			fmt.Fprintln(w, "Blockers:          none")
+		} else {
+			for i, b := range r.Blockers {
+				label := "Blockers:          "
+				if i > 0 {
+					label = util.Spaces(len(label))
+				}
+				fmt.Fprintf(w, "%strack %s is blocked\n", label, b)
+			}
+		}
+		fmt.Fprintf(w, "Tracks (active):   %d\n", r.Tracks)
+		fmt.Fprintf(w, "Phases (total):    %d\n", r.Phases)
+		fmt.Fprintf(w, "Tasks (total):     %d  (%d completed, %d in progress, %d pending)\n",
+			r.Progress.Total, r.Progress.Done, r.Progress.InProgress, r.Progress.Pending)
+		fmt.Fprintf(w, "Progress:          %d/%d (%d%%)\n", r.Progress.Done, r.Progress.Total, r.Percent)
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// writeRefs prints one labelled line per task reference.
+func writeRefs(w io.Writer, label string, refs []taskRef, empty string) {
+	if len(refs) == 0 {
+		fmt.Fprintln(w, label+empty)
+		return
+	}
+	for i, ref := range refs {
+		if i > 0 {
+			label = util.Spaces(len(label))
+		}
+		fmt.Fprintf(w, "%s%s > Phase %d: %s > %s\n", label, ref.TrackID, ref.Phase, ref.Name, ref.Task)
+	}
+}
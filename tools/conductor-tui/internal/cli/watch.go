@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/hooks"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/webhook"
+)
+
+// Watch implements `conductor-tui watch`: it monitors the conductor tree
+// and prints change events, one JSON object per line with --json, and runs
+// the hooks and webhooks configured in conductor/tui.json. It runs until
+// interrupted.
+func Watch(basePath string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	return watch(ctx, basePath, args, stdout, stderr)
+}
+
+func watch(ctx context.Context, basePath string, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "emit newline-delimited JSON events")
+	interval := fs.Duration("interval", 2*time.Second, "how often to re-scan the tracks")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: conductor-tui watch [--json] [--interval 2s]")
+		fs.PrintDefaults()
+	}
+	if _, err := parseArgs(fs, args); err != nil {
+		return 2
+	}
+	if *interval <= 0 {
+		fmt.Fprintln(stderr, "Error: --interval must be positive")
+		return 2
+	}
+
+	cfg, err := config.Load(basePath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	runner := hooks.NewRunner(basePath, cfg.Hooks)
+	outbox := webhook.New(basePath, cfg.Webhooks)
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex // serializes hook and webhook reports on stderr
+	)
+	reportWebhooks := func(r webhook.Result) {
+		if r.Delivered+r.Retrying+r.Failed == 0 {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintln(stderr, formatWebhookResult(r))
+	}
+	if outbox.Enabled() {
+		// Deliveries left over from an earlier run are retried too.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			outbox.Run(ctx, *interval, reportWebhooks)
+		}()
+	}
+	enc := json.NewEncoder(stdout)
+	events.Watch(ctx, basePath, *interval, func(evs []events.Event) {
+		for _, e := range evs {
+			if *asJSON {
+				enc.Encode(e)
+			} else {
+				fmt.Fprintln(stdout, formatEvent(e))
+			}
+		}
+		if outbox.Enabled() {
+			if err := outbox.Enqueue(evs); err != nil {
+				mu.Lock()
+				fmt.Fprintf(stderr, "webhooks: %v\n", err)
+				mu.Unlock()
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if r, ok := outbox.Flush(ctx); ok {
+					reportWebhooks(r)
+				}
+			}()
+		}
+		for _, job := range runner.Jobs(evs) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r := runner.Run(ctx, job)
+				mu.Lock()
+				defer mu.Unlock()
+				fmt.Fprintln(stderr, formatHookResult(r))
+			}()
+		}
+	})
+	wg.Wait()
+	return 0
+}
+
+// formatHookResult summarizes a hook run for the watch log, followed by
+// its output when it failed.
+func formatHookResult(r hooks.Result) string {
+	status := fmt.Sprintf("exit %d", r.ExitCode)
+	if r.Err != nil {
+		status = r.Err.Error()
+	}
+	line := fmt.Sprintf("hook %q on %s %s: %s", r.Hook.Run, r.Event.Type, r.Event.TrackID, status)
+	if r.Failed() && r.Output != "" {
+		line += "\n" + strings.TrimRight(r.Output, "\n")
+	}
+	return line
+}
+
+// formatWebhookResult summarizes a webhook outbox flush for the watch log.
+func formatWebhookResult(r webhook.Result) string {
+	line := fmt.Sprintf("webhooks: %d delivered, %d retrying, %d failed, %d pending", r.Delivered, r.Retrying, r.Failed, r.Pending)
+	if r.LastError != "" {
+		line += " (last error: " + r.LastError + ")"
+	}
+	return line
+}
+
+// formatEvent renders an event as a human-readable log line.
+func formatEvent(e events.Event) string {
+	line := e.Time.Local().Format("15:04:05") + " " + e.TrackID
+	switch e.Type {
+	case events.TrackCreated:
+		line += " created"
+	case events.TrackArchived:
+		line += " archived"
+	case events.TrackStatusChanged:
+		line += fmt.Sprintf(" status %s -> %s", e.From, e.To)
+	case events.TaskStarted:
+		line += fmt.Sprintf(" > Phase %d > %s started", e.Phase, e.Task)
+	case events.TaskCompleted:
+		line += fmt.Sprintf(" > Phase %d > %s completed", e.Phase, e.Task)
+	case events.PhaseCheckpointed:
+		line += fmt.Sprintf(" > Phase %d: %s checkpointed", e.Phase, e.PhaseName)
+	}
+	if e.Commit != "" {
+		line += " (" + e.Commit + ")"
+	}
+	return line
+}
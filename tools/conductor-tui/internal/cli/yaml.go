@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// yamlNode is an order-preserving decoding of a JSON value.
+type yamlNode struct {
+	kind   byte // 'o' object, 'a' array, 's' string, 'l' literal (number, bool, null)
+	keys   []string
+	values []*yamlNode
+	scalar string
+}
+
+// marshalYAML renders v as YAML by way of its JSON encoding, so struct
+// tags and field order match the JSON output exactly.
+func marshalYAML(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	node, err := decodeNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	var b strings.Builder
+	writeYAML(&b, node, 0, false)
+	return []byte(b.String()), nil
+}
+
+func decodeNode(dec *json.Decoder) (*yamlNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			n := &yamlNode{kind: 'o'}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := decodeNode(dec)
+				if err != nil {
+					return nil, err
+				}
+				n.keys = append(n.keys, keyTok.(string))
+				n.values = append(n.values, child)
+			}
+			_, err := dec.Token() // '}'
+			return n, err
+		}
+		n := &yamlNode{kind: 'a'}
+		for dec.More() {
+			child, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.values = append(n.values, child)
+		}
+		_, err := dec.Token() // ']'
+		return n, err
+	case string:
+		return &yamlNode{kind: 's', scalar: t}, nil
+	case json.Number:
+		return &yamlNode{kind: 'l', scalar: t.String()}, nil
+	case bool:
+		return &yamlNode{kind: 'l', scalar: strconv.FormatBool(t)}, nil
+	case nil:
+		return &yamlNode{kind: 'l', scalar: "null"}, nil
+	}
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
+
+// writeYAML writes n at the given indent. inline is true when the caller
+// has already written a "- " or "key: " prefix on the current line.
+func writeYAML(b *strings.Builder, n *yamlNode, indent int, inline bool) {
+	pad := strings.Repeat("  ", indent)
+	switch n.kind {
+	case 'o':
+		if len(n.keys) == 0 {
+			b.WriteString("{}\n")
+			return
+		}
+		for i, k := range n.keys {
+			if i > 0 || !inline {
+				b.WriteString(pad)
+			}
+			b.WriteString(yamlString(k) + ":")
+			writeChild(b, n.values[i], indent)
+		}
+	case 'a':
+		if len(n.values) == 0 {
+			b.WriteString("[]\n")
+			return
+		}
+		for i, v := range n.values {
+			if i > 0 || !inline {
+				b.WriteString(pad)
+			}
+			b.WriteString("-")
+			if v.kind == 'o' && len(v.keys) > 0 {
+				b.WriteString(" ")
+				writeYAML(b, v, indent+1, true)
+			} else {
+				writeChild(b, v, indent)
+			}
+		}
+	case 's':
+		b.WriteString(yamlString(n.scalar) + "\n")
+	default:
+		b.WriteString(n.scalar + "\n")
+	}
+}
+
+// writeChild writes a mapping value or sequence item after its key or dash.
+func writeChild(b *strings.Builder, v *yamlNode, indent int) {
+	if (v.kind == 'o' && len(v.keys) > 0) || (v.kind == 'a' && len(v.values) > 0) {
+		b.WriteString("\n")
+		writeYAML(b, v, indent+1, false)
+		return
+	}
+	b.WriteString(" ")
+	writeYAML(b, v, indent, true)
+}
+
+// yamlString quotes s when it would otherwise be read as something other
+// than a plain string.
+func yamlString(s string) string {
+	if s == "" {
+		return `""`
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "null", "yes", "no", "on", "off", "~":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	if strings.ContainsAny(s, ":#\n\"'{}[],&*!|>%@`\t") ||
+		strings.HasPrefix(s, "-") || strings.HasPrefix(s, "?") ||
+		strings.HasPrefix(s, " ") || strings.HasSuffix(s, " ") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// writeFormatted writes v to w as JSON or YAML, or calls table for the
+// human-readable table format.
+func writeFormatted(w io.Writer, format string, v any, table func(io.Writer)) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		out, err := marshalYAML(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	case "table", "":
+		table(w)
+		return nil
+	}
+	return fmt.Errorf("unknown format %q (want table, json or yaml)", format)
+}
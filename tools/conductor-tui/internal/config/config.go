@@ -0,0 +1,360 @@
+// Package config loads the per-project conductor-tui settings from
+// conductor/tui.json.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// FileName is the config file's path relative to the project root.
+const FileName = "conductor/tui.json"
+
+// Config is the contents of conductor/tui.json. Every section is optional.
+type Config struct {
+	Hooks    Hooks    `json:"hooks"`
+	Webhooks Webhooks `json:"webhooks"`
+	// Fields are project-defined metadata.json fields shown on the edit
+	// screen after the built-in ones.
+	Fields []conductor.Field `json:"fields"`
+	// Statuses and Types are the track statuses and types offered by the
+	// TUI and accepted by set_track_status, in display order.
+	Statuses Vocabulary `json:"statuses"`
+	Types    Vocabulary `json:"types"`
+	// Workflow restricts status changes and names the statuses that set
+	// the lifecycle timestamps.
+	Workflow conductor.Workflow `json:"workflow"`
+}
+
+// Vocabulary is the list of allowed values of a metadata field.
+type Vocabulary []Term
+
+// Term is one value of a Vocabulary and the colour it is shown in: a
+// name from util.Colors, an ANSI colour number or a "#rrggbb" hex code.
+// Without a colour, util.StatusColor picks one. In JSON a term is either
+// {"value": ..., "color": ...} or just the value.
+type Term struct {
+	Value string `json:"value"`
+	Color string `json:"color,omitempty"`
+}
+
+func (t *Term) UnmarshalJSON(b []byte) error {
+	if err := json.Unmarshal(b, &t.Value); err == nil {
+		return nil
+	}
+	type term Term
+	return json.Unmarshal(b, (*term)(t))
+}
+
+// Values returns the values of v in order.
+func (v Vocabulary) Values() []string {
+	values := make([]string, len(v))
+	for i, t := range v {
+		values[i] = t.Value
+	}
+	return values
+}
+
+// Color returns the colour value is shown in: the one configured for it
+// as a status or type, or util.StatusColor's.
+func (c Config) Color(value string) string {
+	for _, t := range append(slices.Clip(c.Statuses), c.Types...) {
+		if t.Value == value && t.Color != "" {
+			return t.Color
+		}
+	}
+	return util.StatusColor(value)
+}
+
+func vocabulary(values []string) Vocabulary {
+	v := make(Vocabulary, len(values))
+	for i, value := range values {
+		v[i] = Term{Value: value}
+	}
+	return v
+}
+
+// Hooks maps change events to shell commands.
+type Hooks struct {
+	// Timeout bounds each command's run time.
+	Timeout Duration `json:"timeout"`
+	// Concurrency is the maximum number of commands running at once.
+	Concurrency int    `json:"concurrency"`
+	Commands    []Hook `json:"commands"`
+}
+
+// Hook runs Run for events of type On. To and Track, when set, restrict
+// it to events with that target status or track ID.
+type Hook struct {
+	On    string `json:"on"`
+	To    string `json:"to,omitempty"`
+	Track string `json:"track,omitempty"`
+	Run   string `json:"run"`
+}
+
+// Matches reports whether the hook applies to e. On may be "*" for every
+// event type.
+func (h Hook) Matches(e events.Event) bool {
+	if h.On != "*" && h.On != e.Type {
+		return false
+	}
+	if h.To != "" && h.To != e.To {
+		return false
+	}
+	if h.Track != "" && h.Track != e.TrackID {
+		return false
+	}
+	return true
+}
+
+// Webhooks configures outbound delivery of change events.
+type Webhooks struct {
+	Endpoints []Endpoint `json:"endpoints"`
+	// Outbox is the directory holding undelivered events, relative to the
+	// project root.
+	Outbox string `json:"outbox,omitempty"`
+	// MaxAttempts is how often a delivery is tried before it is moved to
+	// the outbox's failed directory.
+	MaxAttempts int `json:"max_attempts,omitempty"`
+	// InitialBackoff doubles after every failed attempt up to MaxBackoff.
+	InitialBackoff Duration `json:"initial_backoff,omitempty"`
+	MaxBackoff     Duration `json:"max_backoff,omitempty"`
+	// Timeout bounds each HTTP request.
+	Timeout Duration `json:"timeout,omitempty"`
+}
+
+// Endpoint is an HTTP receiver for events. Requests are signed with
+// Secret, or the value of the SecretEnv environment variable, when set.
+// Events, when set, restricts delivery to those event types.
+type Endpoint struct {
+	URL       string            `json:"url"`
+	Secret    string            `json:"secret,omitempty"`
+	SecretEnv string            `json:"secret_env,omitempty"`
+	Events    []string          `json:"events,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// Wants reports whether the endpoint receives events of the given type.
+func (e Endpoint) Wants(eventType string) bool {
+	return len(e.Events) == 0 || slices.Contains(e.Events, eventType)
+}
+
+// SigningSecret returns the secret used to sign requests, if any.
+func (e Endpoint) SigningSecret() string {
+	if e.Secret != "" {
+		return e.Secret
+	}
+	if e.SecretEnv != "" {
+		return os.Getenv(e.SecretEnv)
+	}
+	return ""
+}
+
+// Duration is a time.Duration read from a JSON string such as "30s".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\"")
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Defaults for unset hook and webhook settings.
+const (
+	DefaultHookTimeout     = time.Minute
+	DefaultHookConcurrency = 2
+
+	DefaultOutbox         = ".conductor-tui/outbox"
+	DefaultMaxAttempts    = 10
+	DefaultInitialBackoff = 5 * time.Second
+	DefaultMaxBackoff     = 10 * time.Minute
+	DefaultWebhookTimeout = 10 * time.Second
+)
+
+// Load reads conductor/tui.json under basePath. A missing file yields the
+// defaults; a malformed one an error naming the file.
+func Load(basePath string) (Config, error) {
+	var cfg Config
+	path := filepath.Join(basePath, filepath.FromSlash(FileName))
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg.withDefaults(), nil
+	}
+	if err != nil {
+		return cfg.withDefaults(), err
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return Config{}.withDefaults(), fmt.Errorf("%s: %w", FileName, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return Config{}.withDefaults(), fmt.Errorf("%s: %w", FileName, err)
+	}
+	return cfg.withDefaults(), nil
+}
+
+func (c Config) withDefaults() Config {
+	if len(c.Statuses) == 0 {
+		c.Statuses = vocabulary(conductor.StatusValues)
+	}
+	if len(c.Types) == 0 {
+		c.Types = vocabulary(conductor.TypeValues)
+	}
+	wf := &c.Workflow
+	if wf.Started == nil {
+		wf.Started = conductor.DefaultWorkflow.Started
+	}
+	if wf.Completed == nil {
+		wf.Completed = conductor.DefaultWorkflow.Completed
+	}
+	if wf.Cancelled == nil {
+		wf.Cancelled = conductor.DefaultWorkflow.Cancelled
+	}
+	if c.Hooks.Timeout <= 0 {
+		c.Hooks.Timeout = Duration(DefaultHookTimeout)
+	}
+	if c.Hooks.Concurrency <= 0 {
+		c.Hooks.Concurrency = DefaultHookConcurrency
+	}
+	w := &c.Webhooks
+	if w.Outbox == "" {
+		w.Outbox = DefaultOutbox
+	}
+	if w.MaxAttempts <= 0 {
+		w.MaxAttempts = DefaultMaxAttempts
+	}
+	if w.InitialBackoff <= 0 {
+		w.InitialBackoff = Duration(DefaultInitialBackoff)
+	}
+	if w.MaxBackoff <= 0 {
+		w.MaxBackoff = Duration(DefaultMaxBackoff)
+	}
+	if w.Timeout <= 0 {
+		w.Timeout = Duration(DefaultWebhookTimeout)
+	}
+	return c
+}
+
+func (c Config) validate() error {
+	for i, h := range c.Hooks.Commands {
+		if h.On != "*" && !slices.Contains(events.Types, h.On) {
+			return fmt.Errorf("hooks.commands[%d]: unknown event %q", i, h.On)
+		}
+		if h.Run == "" {
+			return fmt.Errorf("hooks.commands[%d]: run is required", i)
+		}
+	}
+	for i, e := range c.Webhooks.Endpoints {
+		u, err := url.Parse(e.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("webhooks.endpoints[%d]: url must be an http or https URL", i)
+		}
+		for _, t := range e.Events {
+			if !slices.Contains(events.Types, t) {
+				return fmt.Errorf("webhooks.endpoints[%d]: unknown event %q", i, t)
+			}
+		}
+	}
+	seen := map[string]bool{}
+	for i, f := range c.Fields {
+		if err := f.Check(); err != nil {
+			return fmt.Errorf("fields[%d]: %w", i, err)
+		}
+		if slices.Contains(conductor.MetadataKeys, f.Key) {
+			return fmt.Errorf("fields[%d]: %q is a built-in metadata field", i, f.Key)
+		}
+		if seen[f.Key] {
+			return fmt.Errorf("fields[%d]: duplicate key %q", i, f.Key)
+		}
+		seen[f.Key] = true
+	}
+	vocabularies := []struct {
+		name string
+		v    Vocabulary
+	}{{"statuses", c.Statuses}, {"types", c.Types}}
+	for _, voc := range vocabularies {
+		if err := voc.v.validate(voc.name); err != nil {
+			return err
+		}
+	}
+	return c.validateWorkflow()
+}
+
+// validateWorkflow checks that the workflow only names known statuses.
+func (c Config) validateWorkflow() error {
+	statuses := conductor.StatusValues
+	if len(c.Statuses) > 0 {
+		statuses = c.Statuses.Values()
+	}
+	check := func(where, status string) error {
+		if !slices.Contains(statuses, status) {
+			return fmt.Errorf("workflow.%s: unknown status %q", where, status)
+		}
+		return nil
+	}
+	for _, from := range slices.Sorted(maps.Keys(c.Workflow.Transitions)) {
+		if err := check("transitions", from); err != nil {
+			return err
+		}
+		for _, to := range c.Workflow.Transitions[from] {
+			if err := check("transitions."+from, to); err != nil {
+				return err
+			}
+		}
+	}
+	lifecycle := []struct {
+		name string
+		list []string
+	}{{"started", c.Workflow.Started}, {"completed", c.Workflow.Completed}, {"cancelled", c.Workflow.Cancelled}}
+	for _, lc := range lifecycle {
+		for _, status := range lc.list {
+			if err := check(lc.name, status); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func (v Vocabulary) validate(name string) error {
+	seen := map[string]bool{}
+	for i, t := range v {
+		if t.Value == "" {
+			return fmt.Errorf("%s[%d]: value is required", name, i)
+		}
+		if seen[t.Value] {
+			return fmt.Errorf("%s[%d]: duplicate value %q", name, i, t.Value)
+		}
+		seen[t.Value] = true
+		if n, err := strconv.Atoi(t.Color); t.Color != "" && !slices.Contains(util.Colors, t.Color) &&
+			!hexColorRe.MatchString(t.Color) && (err != nil || n < 0 || n > 255) {
+			return fmt.Errorf("%s[%d]: unknown color %q", name, i, t.Color)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	base := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(base, "conductor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "conductor", "tui.json"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return base
+}
+
+func TestLoad_MissingUsesDefaults(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if time.Duration(cfg.Hooks.Timeout) != DefaultHookTimeout || cfg.Hooks.Concurrency != DefaultHookConcurrency {
+		t.Errorf("defaults = %+v", cfg.Hooks)
+	}
+}
+
+func TestLoad_Hooks(t *testing.T) {
+	base := writeConfig(t, `{
+  "hooks": {
+    "timeout": "5s",
+    "concurrency": 3,
+    "commands": [
+      {"on": "task_completed", "run": "make test"},
+      {"on": "track_status_changed", "to": "completed", "run": "./notify.sh"}
+    ]
+  }
+}`)
+	cfg, err := Load(base)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if time.Duration(cfg.Hooks.Timeout) != 5*time.Second || cfg.Hooks.Concurrency != 3 {
+		t.Errorf("hooks = %+v", cfg.Hooks)
+	}
+	if len(cfg.Hooks.Commands) != 2 || cfg.Hooks.Commands[1].To != "completed" {
+		t.Errorf("commands = %+v", cfg.Hooks.Commands)
+	}
+}
+
+func TestLoad_Webhooks(t *testing.T) {
+	t.Setenv("CONDUCTOR_TEST_SECRET", "from-env")
+	base := writeConfig(t, `{
+  "webhooks": {
+    "max_attempts": 3,
+    "endpoints": [
+      {"url": "https://example.com/hook", "secret": "s3cret", "events": ["task_completed"]},
+      {"url": "http://localhost:9000", "secret_env": "CONDUCTOR_TEST_SECRET"}
+    ]
+  }
+}`)
+	cfg, err := Load(base)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	w := cfg.Webhooks
+	if w.MaxAttempts != 3 || w.Outbox != DefaultOutbox || time.Duration(w.InitialBackoff) != DefaultInitialBackoff {
+		t.Errorf("webhooks = %+v", w)
+	}
+	if !w.Endpoints[0].Wants(events.TaskCompleted) || w.Endpoints[0].Wants(events.TaskStarted) {
+		t.Error("endpoint 0 should only want task_completed")
+	}
+	if !w.Endpoints[1].Wants(events.TrackArchived) {
+		t.Error("endpoint without events should want everything")
+	}
+	if w.Endpoints[0].SigningSecret() != "s3cret" || w.Endpoints[1].SigningSecret() != "from-env" {
+		t.Errorf("secrets = %q, %q", w.Endpoints[0].SigningSecret(), w.Endpoints[1].SigningSecret())
+	}
+}
+
+func TestLoad_Fields(t *testing.T) {
+	base := writeConfig(t, `{
+  "fields": [
+    {"key": "priority", "kind": "enum", "options": ["low", "medium", "high"]},
+    {"key": "target_release", "label": "Target release", "kind": "date"},
+    {"key": "labels", "kind": "list"}
+  ]
+}`)
+	cfg, err := Load(base)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if len(cfg.Fields) != 3 || cfg.Fields[0].Options[2] != "high" || cfg.Fields[1].Name() != "Target release" {
+		t.Errorf("fields = %+v", cfg.Fields)
+	}
+}
+
+func TestLoad_Vocabularies(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !slices.Equal(cfg.Statuses.Values(), conductor.StatusValues) || !slices.Equal(cfg.Types.Values(), conductor.TypeValues) {
+		t.Errorf("defaults = %v, %v", cfg.Statuses.Values(), cfg.Types.Values())
+	}
+
+	base := writeConfig(t, `{
+  "statuses": ["todo", "doing", {"value": "review", "color": "#8a2be2"}, {"value": "blocked", "color": "red"}, "done"],
+  "types": ["feature", {"value": "spike", "color": "214"}]
+}`)
+	if cfg, err = Load(base); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := cfg.Statuses.Values(); !slices.Equal(got, []string{"todo", "doing", "review", "blocked", "done"}) {
+		t.Errorf("statuses = %v", got)
+	}
+	for value, want := range map[string]string{"review": "#8a2be2", "spike": "214", "doing": "yellow", "feature": ""} {
+		if got := cfg.Color(value); got != want {
+			t.Errorf("Color(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestLoad_Workflow(t *testing.T) {
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !slices.Equal(cfg.Workflow.Started, conductor.DefaultWorkflow.Started) || cfg.Workflow.Transitions != nil {
+		t.Errorf("default workflow = %+v", cfg.Workflow)
+	}
+
+	base := writeConfig(t, `{
+  "statuses": ["todo", "doing", "done"],
+  "workflow": {"transitions": {"todo": ["doing"], "doing": ["todo", "done"]}, "started": ["doing"], "completed": ["done"], "cancelled": []}
+}`)
+	if cfg, err = Load(base); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	w := cfg.Workflow
+	if !w.Allows("todo", "doing") || w.Allows("todo", "done") || !w.Allows("done", "todo") {
+		t.Errorf("transitions = %v", w.Transitions)
+	}
+	if !slices.Equal(w.Completed, []string{"done"}) || len(w.Cancelled) != 0 {
+		t.Errorf("workflow = %+v", w)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown event": `{"hooks": {"commands": [{"on": "task_done", "run": "x"}]}}`,
+		"missing run":   `{"hooks": {"commands": [{"on": "task_completed"}]}}`,
+		"bad duration":  `{"hooks": {"timeout": 30}}`,
+		"bad json":      `{`,
+		"webhook url":   `{"webhooks": {"endpoints": [{"url": "ftp://example.com"}]}}`,
+		"webhook event": `{"webhooks": {"endpoints": [{"url": "https://example.com", "events": ["nope"]}]}}`,
+		"field kind":    `{"fields": [{"key": "owner", "kind": "person"}]}`,
+		"enum options":  `{"fields": [{"key": "priority", "kind": "enum"}]}`,
+		"built-in key":  `{"fields": [{"key": "status", "kind": "text"}]}`,
+		"duplicate key": `{"fields": [{"key": "owner", "kind": "text"}, {"key": "owner", "kind": "text"}]}`,
+		"status color":  `{"statuses": [{"value": "blocked", "color": "crimson"}]}`,
+		"empty type":    `{"types": [""]}`,
+		"transition to": `{"workflow": {"transitions": {"new": ["doing"]}}}`,
+		"started":       `{"workflow": {"started": ["begun"]}}`,
+	} {
+		_, err := Load(writeConfig(t, content))
+		if err == nil || !strings.Contains(err.Error(), "conductor/tui.json") {
+			t.Errorf("%s: err = %v, want error naming the file", name, err)
+		}
+	}
+}
+
+func TestLoad_InvalidReportsFirstInOrder(t *testing.T) {
+	for content, want := range map[string]string{
+		`{"statuses": [""], "types": [""]}`:                                        "statuses[0]",
+		`{"workflow": {"started": ["x"], "completed": ["y"], "cancelled": ["z"]}}`: "workflow.started",
+	} {
+		for range 10 {
+			_, err := Load(writeConfig(t, content))
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Fatalf("%s: err = %v, want it to name %s", content, err, want)
+			}
+		}
+	}
+}
+
+func TestHook_Matches(t *testing.T) {
+	e := events.Event{Type: events.TrackStatusChanged, TrackID: "a", From: "in_progress", To: "completed"}
+	for _, tc := range []struct {
+		hook Hook
+		want bool
+	}{
+		{Hook{On: events.TrackStatusChanged}, true},
+		{Hook{On: "*"}, true},
+		{Hook{On: events.TrackStatusChanged, To: "completed"}, true},
+		{Hook{On: events.TrackStatusChanged, To: "blocked"}, false},
+		{Hook{On: events.TrackStatusChanged, Track: "b"}, false},
+		{Hook{On: events.TaskCompleted}, false},
+	} {
+		if got := tc.hook.Matches(e); got != tc.want {
+			t.Errorf("%+v.Matches = %v, want %v", tc.hook, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package events derives change events by diffing successive track
+// discovery snapshots.
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Event types.
+const (
+	TrackCreated       = "track_created"
+	TrackStatusChanged = "track_status_changed"
+	TaskStarted        = "task_started"
+	TaskCompleted      = "task_completed"
+	PhaseCheckpointed  = "phase_checkpointed"
+	TrackArchived      = "track_archived"
+)
+
+// Types lists every event type.
+var Types = []string{TrackCreated, TrackStatusChanged, TaskStarted, TaskCompleted, PhaseCheckpointed, TrackArchived}
+
+// Event is a single change to a track. Phase fields are set for task and
+// phase events, Task for task events, From and To for status changes, and
+// Commit for completed tasks and checkpoints when recorded.
+type Event struct {
+	Type        string    `json:"type"`
+	Time        time.Time `json:"time"`
+	TrackID     string    `json:"track_id"`
+	Description string    `json:"description,omitempty"`
+	Phase       int       `json:"phase,omitempty"`
+	PhaseName   string    `json:"phase_name,omitempty"`
+	Task        string    `json:"task,omitempty"`
+	From        string    `json:"from,omitempty"`
+	To          string    `json:"to,omitempty"`
+	Commit      string    `json:"commit,omitempty"`
+}
+
+// Diff returns the events that turn prev into next, stamped with now.
+// Tracks are matched by ID, phases by number and tasks by phase number
+// and name. Tracks that disappear produce no event.
+func Diff(prev, next []conductor.Track, now time.Time) []Event {
+	before := make(map[string]conductor.Track, len(prev))
+	for _, t := range prev {
+		before[t.TrackID] = t
+	}
+
+	var out []Event
+	for _, t := range next {
+		base := Event{Time: now, TrackID: t.TrackID, Description: t.Description}
+		old, ok := before[t.TrackID]
+		if !ok {
+			e := base
+			e.Type = TrackCreated
+			e.To = t.Status
+			out = append(out, e)
+			continue
+		}
+		if old.Source != "archived" && t.Source == "archived" {
+			e := base
+			e.Type = TrackArchived
+			out = append(out, e)
+		}
+		if old.Status != t.Status {
+			e := base
+			e.Type = TrackStatusChanged
+			e.From, e.To = old.Status, t.Status
+			out = append(out, e)
+		}
+
+		oldPhases := map[int]conductor.Phase{}
+		for _, p := range old.Phases {
+			oldPhases[p.Number] = p
+		}
+		for _, p := range t.Phases {
+			oldPhase := oldPhases[p.Number]
+			oldTasks := map[string]conductor.Task{}
+			for _, task := range oldPhase.Tasks {
+				oldTasks[task.Name] = task
+			}
+			pe := base
+			pe.Phase, pe.PhaseName = p.Number, p.Name
+			for _, task := range p.Tasks {
+				was := oldTasks[task.Name]
+				e := pe
+				e.Task = task.Name
+				switch {
+				case task.Completed && !was.Completed:
+					e.Type = TaskCompleted
+					e.Commit = task.Commit
+				case task.InProgress && !was.InProgress && !was.Completed:
+					e.Type = TaskStarted
+				default:
+					continue
+				}
+				out = append(out, e)
+			}
+			if p.Checkpoint != "" && oldPhase.Checkpoint == "" {
+				e := pe
+				e.Type = PhaseCheckpointed
+				e.Commit = p.Checkpoint
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
+
+// Watch discovers tracks under basePath every interval and calls emit
+// with the events since the previous scan, until ctx is cancelled. The
+// first scan is the baseline and emits nothing.
+func Watch(ctx context.Context, basePath string, interval time.Duration, emit func([]Event)) {
+	prev := conductor.DiscoverTracks(basePath)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			next := conductor.DiscoverTracks(basePath)
+			if evs := Diff(prev, next, now.UTC()); len(evs) > 0 {
+				emit(evs)
+			}
+			prev = next
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func track(id, status, source string, phases ...conductor.Phase) conductor.Track {
+	return conductor.Track{TrackID: id, Status: status, Source: source, Phases: phases}
+}
+
+func types(evs []Event) []string {
+	var out []string
+	for _, e := range evs {
+		out = append(out, e.Type)
+	}
+	return out
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	tracks := []conductor.Track{track("a", "new", "active")}
+	if evs := Diff(tracks, tracks, now); len(evs) != 0 {
+		t.Errorf("got %v, want no events", types(evs))
+	}
+}
+
+func TestDiff_TrackLifecycle(t *testing.T) {
+	prev := []conductor.Track{track("a", "in_progress", "active")}
+	next := []conductor.Track{
+		track("a", "completed", "archived"),
+		track("b", "new", "active"),
+	}
+	evs := Diff(prev, next, now)
+	want := []string{TrackArchived, TrackStatusChanged, TrackCreated}
+	if len(evs) != len(want) {
+		t.Fatalf("got %v, want %v", types(evs), want)
+	}
+	for i := range want {
+		if evs[i].Type != want[i] {
+			t.Errorf("event %d = %s, want %s", i, evs[i].Type, want[i])
+		}
+	}
+	if evs[1].From != "in_progress" || evs[1].To != "completed" {
+		t.Errorf("status change = %s -> %s", evs[1].From, evs[1].To)
+	}
+	if evs[2].TrackID != "b" || !evs[2].Time.Equal(now) {
+		t.Errorf("created event = %+v", evs[2])
+	}
+}
+
+func TestDiff_TasksAndCheckpoint(t *testing.T) {
+	prev := []conductor.Track{track("a", "in_progress", "active",
+		conductor.Phase{Number: 1, Name: "Build", Tasks: []conductor.Task{
+			{Name: "One", InProgress: true},
+			{Name: "Two"},
+		}})}
+	next := []conductor.Track{track("a", "in_progress", "active",
+		conductor.Phase{Number: 1, Name: "Build", Checkpoint: "def5678", Tasks: []conductor.Task{
+			{Name: "One", Completed: true, Commit: "abc1234"},
+			{Name: "Two", InProgress: true},
+		}})}
+
+	evs := Diff(prev, next, now)
+	if len(evs) != 3 {
+		t.Fatalf("got %v, want task_completed, task_started, phase_checkpointed", types(evs))
+	}
+	if evs[0].Type != TaskCompleted || evs[0].Task != "One" || evs[0].Commit != "abc1234" || evs[0].Phase != 1 {
+		t.Errorf("evs[0] = %+v", evs[0])
+	}
+	if evs[1].Type != TaskStarted || evs[1].Task != "Two" {
+		t.Errorf("evs[1] = %+v", evs[1])
+	}
+	if evs[2].Type != PhaseCheckpointed || evs[2].Commit != "def5678" || evs[2].PhaseName != "Build" {
+		t.Errorf("evs[2] = %+v", evs[2])
+	}
+}
+
+func TestWatch_EmitsOnChange(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "conductor", "tracks", "live_20260301")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("metadata.json", `{"track_id": "live_20260301", "status": "in_progress"}`)
+	write("plan.md", "## Phase 1: Build\n\n- [ ] Task: One\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	got := make(chan []Event, 10)
+	go Watch(ctx, base, 10*time.Millisecond, func(evs []Event) { got <- evs })
+
+	time.Sleep(30 * time.Millisecond) // let the baseline scan run
+	write("plan.md", "## Phase 1: Build\n\n- [x] Task: One `abc1234`\n")
+
+	select {
+	case evs := <-got:
+		if len(evs) != 1 || evs[0].Type != TaskCompleted || evs[0].TrackID != "live_20260301" {
+			t.Errorf("got %+v", evs)
+		}
+	case <-ctx.Done():
+		t.Fatal("no events after plan.md changed")
+	}
+}
@@ -0,0 +1,213 @@
+// Package export renders tracks as Markdown, HTML or CSV status reports.
+// Markdown and HTML are produced from text/template files that users can
+// override.
+package export
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+// Formats lists the supported output formats.
+var Formats = []string{"markdown", "html", "csv"}
+
+// Levels lists the supported CSV row levels.
+var Levels = []string{"track", "phase", "task"}
+
+// Report is the data passed to report templates.
+type Report struct {
+	Title       string
+	GeneratedAt time.Time
+	Tracks      []TrackReport
+	Totals      conductor.Progress
+}
+
+// TrackReport is a track with its task progress and per-phase summaries.
+type TrackReport struct {
+	conductor.Track
+	Progress conductor.Progress
+	Phases   []PhaseReport
+}
+
+// PhaseReport is a phase with its derived status and task progress.
+type PhaseReport struct {
+	conductor.Phase
+	Status   string
+	Progress conductor.Progress
+}
+
+// Build assembles the report for tracks at time now.
+func Build(tracks []conductor.Track, now time.Time) Report {
+	r := Report{Title: "Conductor Status Report", GeneratedAt: now}
+	for _, t := range tracks {
+		tr := TrackReport{Track: t, Progress: conductor.TrackProgress(t)}
+		for _, p := range t.Phases {
+			tr.Phases = append(tr.Phases, PhaseReport{
+				Phase:    p,
+				Status:   conductor.PhaseStatus(p),
+				Progress: conductor.TrackProgress(conductor.Track{Phases: []conductor.Phase{p}}),
+			})
+		}
+		r.Totals.Total += tr.Progress.Total
+		r.Totals.Done += tr.Progress.Done
+		r.Totals.InProgress += tr.Progress.InProgress
+		r.Totals.Pending += tr.Progress.Pending
+		r.Tracks = append(r.Tracks, tr)
+	}
+	return r
+}
+
+// Extension returns the file extension for format, e.g. ".md".
+func Extension(format string) string {
+	switch format {
+	case "markdown":
+		return ".md"
+	case "html":
+		return ".html"
+	case "csv":
+		return ".csv"
+	}
+	return ""
+}
+
+// TemplatePath returns where a project-level template override for
+// format lives: conductor/templates/export.md.tmpl or export.html.tmpl.
+func TemplatePath(basePath, format string) string {
+	return filepath.Join(basePath, "conductor", "templates", "export"+Extension(format)+".tmpl")
+}
+
+// Options selects the output format. Level applies to CSV only; Template
+// is the path of a text/template file replacing the built-in Markdown or
+// HTML template.
+type Options struct {
+	Format   string
+	Level    string
+	Template string
+}
+
+// Write renders r to w.
+func Write(w io.Writer, r Report, opts Options) error {
+	switch opts.Format {
+	case "markdown", "html":
+		tmpl, err := loadTemplate(opts.Format, opts.Template)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, r)
+	case "csv":
+		return writeCSV(w, r, opts.Level)
+	}
+	return fmt.Errorf("unknown format %q (want markdown, html or csv)", opts.Format)
+}
+
+// funcs are the helpers available to report templates.
+var funcs = template.FuncMap{
+	"checkbox": func(t conductor.Task) string {
+		switch {
+		case t.Completed:
+			return "[x]"
+		case t.InProgress:
+			return "[~]"
+		}
+		return "[ ]"
+	},
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return "-"
+		}
+		return t.Format("2006-01-02")
+	},
+	"color": util.StatusColor,
+}
+
+func loadTemplate(format, path string) (*template.Template, error) {
+	name := "summary.md.tmpl"
+	if format == "html" {
+		name = "report.html.tmpl"
+	}
+	var src []byte
+	var err error
+	if path != "" {
+		src, err = os.ReadFile(path)
+	} else {
+		src, err = templates.ReadFile("templates/" + name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		name = filepath.Base(path)
+	}
+	return template.New(name).Funcs(funcs).Parse(string(src))
+}
+
+func writeCSV(w io.Writer, r Report, level string) error {
+	cw := csv.NewWriter(w)
+	itoa := strconv.Itoa
+	switch level {
+	case "track", "":
+		cw.Write([]string{"track_id", "type", "status", "source", "description", "created_at", "updated_at",
+			"phases", "tasks_total", "tasks_done", "tasks_in_progress", "percent"})
+		for _, t := range r.Tracks {
+			cw.Write([]string{t.TrackID, t.Type, t.Status, t.Source, t.Description,
+				csvTime(t.CreatedAt), csvTime(t.UpdatedAt), itoa(len(t.Phases)),
+				itoa(t.Progress.Total), itoa(t.Progress.Done), itoa(t.Progress.InProgress), itoa(t.Progress.Percent())})
+		}
+	case "phase":
+		cw.Write([]string{"track_id", "phase", "name", "status", "checkpoint",
+			"tasks_total", "tasks_done", "tasks_in_progress", "percent"})
+		for _, t := range r.Tracks {
+			for _, p := range t.Phases {
+				cw.Write([]string{t.TrackID, itoa(p.Number), p.Name, p.Status, p.Checkpoint,
+					itoa(p.Progress.Total), itoa(p.Progress.Done), itoa(p.Progress.InProgress), itoa(p.Progress.Percent())})
+			}
+		}
+	case "task":
+		cw.Write([]string{"track_id", "phase", "phase_name", "task", "status", "commit",
+			"subtasks_total", "subtasks_done"})
+		for _, t := range r.Tracks {
+			for _, p := range t.Phases {
+				for _, task := range p.Tasks {
+					status := "pending"
+					if task.Completed {
+						status = "completed"
+					} else if task.InProgress {
+						status = "in_progress"
+					}
+					done := 0
+					for _, st := range task.SubTasks {
+						if st.Completed {
+							done++
+						}
+					}
+					cw.Write([]string{t.TrackID, itoa(p.Number), p.Name, task.Name, status, task.Commit,
+						itoa(len(task.SubTasks)), itoa(done)})
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("unknown level %q (want track, phase or task)", level)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+func testReport() Report {
+	tracks := []conductor.Track{
+		{TrackID: "auth_20260301", Type: "feature", Status: "in_progress", Description: "Login <flow>",
+			Phases: []conductor.Phase{
+				{Number: 1, Name: "Setup", Checkpoint: "abc1234", Tasks: []conductor.Task{
+					{Name: "Init", Completed: true, Commit: "abc1234"},
+				}},
+				{Number: 2, Name: "Build", Tasks: []conductor.Task{
+					{Name: "Forms", InProgress: true, SubTasks: []conductor.SubTask{{Name: "a", Completed: true}, {Name: "b"}}},
+					{Name: "Tests"},
+				}},
+			}},
+		{TrackID: "fix_20260302", Type: "bug", Status: "new"},
+	}
+	return Build(tracks, time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC))
+}
+
+func render(t *testing.T, opts Options) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := Write(&buf, testReport(), opts); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestBuild_Progress(t *testing.T) {
+	r := testReport()
+	if r.Totals.Total != 3 || r.Totals.Done != 1 || r.Totals.InProgress != 1 {
+		t.Errorf("totals = %+v", r.Totals)
+	}
+	p := r.Tracks[0].Phases[1]
+	if p.Status != "pending" || p.Progress.Total != 2 || p.Progress.InProgress != 1 {
+		t.Errorf("phase 2 = %s %+v", p.Status, p.Progress)
+	}
+}
+
+func TestWrite_Markdown(t *testing.T) {
+	out := render(t, Options{Format: "markdown"})
+	for _, want := range []string{
+		"# Conductor Status Report",
+		"| auth_20260301 | feature | in_progress | 1/3 (33%) | - |",
+		"### Phase 1: Setup (completed, checkpoint `abc1234`)",
+		"- [~] Forms",
+		"- [x] Init `abc1234`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWrite_HTMLSelfContained(t *testing.T) {
+	out := render(t, Options{Format: "html"})
+	if !strings.Contains(out, `<span style="width: 33%"></span>`) {
+		t.Error("missing track progress bar")
+	}
+	if !strings.Contains(out, "Login &lt;flow&gt;") {
+		t.Error("descriptions must be HTML-escaped")
+	}
+	for _, external := range []string{"<link", "<script", "src="} {
+		if strings.Contains(out, external) {
+			t.Errorf("HTML should be self-contained, found %q", external)
+		}
+	}
+}
+
+func TestWrite_CSVLevels(t *testing.T) {
+	for _, tc := range []struct {
+		level string
+		rows  int
+		first string
+	}{
+		{"track", 3, "auth_20260301,feature,in_progress"},
+		{"phase", 3, "auth_20260301,1,Setup,completed,abc1234,1,1,0,100"},
+		{"task", 4, "auth_20260301,1,Setup,Init,completed,abc1234,0,0"},
+	} {
+		out := render(t, Options{Format: "csv", Level: tc.level})
+		rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: invalid CSV: %v", tc.level, err)
+		}
+		if len(rows) != tc.rows {
+			t.Errorf("%s: got %d rows, want %d", tc.level, len(rows), tc.rows)
+		}
+		if !strings.HasPrefix(strings.Split(out, "\n")[1], tc.first) {
+			t.Errorf("%s: first row = %q, want prefix %q", tc.level, strings.Split(out, "\n")[1], tc.first)
+		}
+	}
+	if err := Write(&bytes.Buffer{}, testReport(), Options{Format: "csv", Level: "subtask"}); err == nil {
+		t.Error("expected error for unknown level")
+	}
+}
+
+func TestWrite_TemplateOverride(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weekly.md.tmpl")
+	tmpl := "{{range .Tracks}}{{.TrackID}}={{.Progress.Percent}}% {{end}}"
+	if err := os.WriteFile(path, []byte(tmpl), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := render(t, Options{Format: "markdown", Template: path})
+	if out != "auth_20260301=33% fix_20260302=0% " {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestTemplatePath(t *testing.T) {
+	got := TemplatePath("/p", "html")
+	if got != filepath.Join("/p", "conductor", "templates", "export.html.tmpl") {
+		t.Errorf("TemplatePath = %q", got)
+	}
+}
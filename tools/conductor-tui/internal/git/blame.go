@@ -0,0 +1,92 @@
+package git
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameLine attributes one line of a file to the commit that last changed
+// it, which is not necessarily the one that changed its checkbox.
+type BlameLine struct {
+	Commit      string // full SHA; all zeros for uncommitted changes
+	Author      string
+	AuthorEmail string
+	AuthorTime  time.Time
+	Summary     string
+}
+
+// Uncommitted reports whether the line has local changes not yet committed.
+func (b BlameLine) Uncommitted() bool {
+	return strings.Trim(b.Commit, "0") == ""
+}
+
+// Blame runs git blame on path (including working-tree changes) and returns
+// the attribution for each line keyed by 1-based line number.
+func Blame(path string) (map[int]BlameLine, error) {
+	out, err := Run(filepath.Dir(path), "blame", "--porcelain", "--", filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	return parseBlame(out), nil
+}
+
+// parseBlame parses git blame --porcelain output. Commit details are only
+// printed the first time a commit appears, so they are cached per SHA.
+func parseBlame(out string) map[int]BlameLine {
+	result := map[int]BlameLine{}
+	commits := map[string]*BlameLine{}
+	var current *BlameLine
+	lineNo := 0
+
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "\t") {
+			if current != nil {
+				result[lineNo] = *current
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) >= 3 && len(fields[0]) == 40 && isHex(fields[0]) {
+			sha := fields[0]
+			lineNo, _ = strconv.Atoi(fields[2])
+			c, ok := commits[sha]
+			if !ok {
+				c = &BlameLine{Commit: sha}
+				commits[sha] = c
+			}
+			current = c
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.AuthorEmail = strings.Trim(value, "<>")
+		case "author-time":
+			if sec, err := strconv.ParseInt(value, 10, 64); err == nil {
+				current.AuthorTime = time.Unix(sec, 0).UTC()
+			}
+		case "summary":
+			current.Summary = value
+		}
+	}
+	return result
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Commit describes a single commit.
+type Commit struct {
+	SHA         string
+	Author      string
+	AuthorEmail string
+	AuthorTime  time.Time
+	Subject     string
+	Body        string
+}
+
+// LookupCommit resolves rev (typically an abbreviated SHA) in the
+// repository containing dir.
+func LookupCommit(dir, rev string) (Commit, error) {
+	out, err := Run(dir, "show", "-s", "--format=%H%x00%an%x00%ae%x00%at%x00%s%x00%b", rev+"^{commit}", "--")
+	if err != nil {
+		return Commit{}, err
+	}
+	f := strings.SplitN(out, "\x00", 6)
+	if len(f) != 6 {
+		return Commit{}, fmt.Errorf("git show: unexpected output for %s", rev)
+	}
+	c := Commit{SHA: f[0], Author: f[1], AuthorEmail: f[2], Subject: f[4], Body: strings.TrimSpace(f[5])}
+	if sec, err := strconv.ParseInt(f[3], 10, 64); err == nil {
+		c.AuthorTime = time.Unix(sec, 0).UTC()
+	}
+	return c, nil
+}
@@ -0,0 +1,43 @@
+// Package git provides thin wrappers around the git command line used to
+// read Conductor state from repository history.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Run executes git with the given arguments in dir and returns its stdout.
+// On failure the returned error includes git's stderr output.
+func Run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return stdout.String(), nil
+}
+
+// Root returns the top-level directory of the repository containing dir.
+func Root(dir string) (string, error) {
+	out, err := Run(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Show returns the content of path at the given revision. The path is
+// relative to the repository root.
+func Show(dir, rev, path string) (string, error) {
+	return Run(dir, "show", rev+":"+path)
+}
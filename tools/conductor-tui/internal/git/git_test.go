@@ -0,0 +1,312 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRepo creates a temporary git repository with an initial commit on
+// branch "main" containing one track under project/conductor/tracks.
+func testRepo(t *testing.T) (root, project string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root = t.TempDir()
+	project = filepath.Join(root, "project")
+	gitCmd(t, root, "init", "-q", "-b", "main")
+	writeTrack(t, project, "tracks", "alpha_20260101", "- [ ] Task: One\n- [ ] Task: Two\n")
+	commitAll(t, root, "add alpha")
+	return root, project
+}
+
+// gitCmd runs git in dir with a fixed identity.
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out)
+}
+
+func commitAll(t *testing.T, dir, msg string) {
+	t.Helper()
+	gitCmd(t, dir, "add", "-A")
+	gitCmd(t, dir, "commit", "-q", "-m", msg)
+}
+
+// writeTrack writes metadata.json and a one-phase plan.md with the given
+// task lines under project/conductor/<dir>/<id>.
+func writeTrack(t *testing.T, project, dir, id, tasks string) {
+	t.Helper()
+	trackDir := filepath.Join(project, "conductor", dir, id)
+	if err := os.MkdirAll(trackDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := `{"track_id": "` + id + `", "type": "feature", "status": "in_progress"}`
+	if err := os.WriteFile(filepath.Join(trackDir, "metadata.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plan := "## Phase 1: Work\n\n" + tasks
+	if err := os.WriteFile(filepath.Join(trackDir, "plan.md"), []byte(plan), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRoot(t *testing.T) {
+	root, project := testRepo(t)
+	got, err := Root(project)
+	if err != nil {
+		t.Fatalf("Root returned error: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(root)
+	if got != want {
+		t.Errorf("Root = %q, want %q", got, want)
+	}
+}
+
+func TestRun_ErrorIncludesStderr(t *testing.T) {
+	_, project := testRepo(t)
+	_, err := Run(project, "show", "nonexistent-ref:file")
+	if err == nil {
+		t.Fatal("expected error for missing ref")
+	}
+	if !strings.Contains(err.Error(), "git show") {
+		t.Errorf("error should name the git subcommand, got %v", err)
+	}
+}
+
+func TestLookupCommit(t *testing.T) {
+	root, project := testRepo(t)
+	sha := strings.TrimSpace(gitCmd(t, root, "rev-parse", "HEAD"))
+	c, err := LookupCommit(project, sha[:7])
+	if err != nil {
+		t.Fatalf("LookupCommit returned error: %v", err)
+	}
+	if c.SHA != sha || c.Author != "Test" || c.AuthorEmail != "test@example.com" || c.Subject != "add alpha" || c.AuthorTime.IsZero() {
+		t.Errorf("commit = %+v", c)
+	}
+	if _, err := LookupCommit(project, "deadbee"); err == nil {
+		t.Error("expected error for unknown SHA")
+	}
+}
+
+func TestRefs(t *testing.T) {
+	root, _ := testRepo(t)
+	gitCmd(t, root, "branch", "feature")
+
+	refs, err := Refs(root)
+	if err != nil {
+		t.Fatalf("Refs returned error: %v", err)
+	}
+	if strings.Join(refs, ",") != "feature,main" {
+		t.Errorf("Refs = %v, want [feature main]", refs)
+	}
+	if got := CurrentBranch(root); got != "main" {
+		t.Errorf("CurrentBranch = %q, want %q", got, "main")
+	}
+}
+
+func TestDiscoverTracks_AtRef(t *testing.T) {
+	root, project := testRepo(t)
+	gitCmd(t, root, "checkout", "-q", "-b", "feature")
+	writeTrack(t, project, "tracks", "alpha_20260101", "- [x] Task: One `abc1234`\n- [~] Task: Two\n")
+	writeTrack(t, project, "archive", "beta_20251201", "- [x] Task: Done\n")
+	commitAll(t, root, "progress on feature")
+	gitCmd(t, root, "checkout", "-q", "main")
+
+	tracks, err := DiscoverTracks(project, "feature")
+	if err != nil {
+		t.Fatalf("DiscoverTracks returned error: %v", err)
+	}
+	if len(tracks) != 2 {
+		t.Fatalf("got %d tracks, want 2", len(tracks))
+	}
+	if tracks[0].TrackID != "alpha_20260101" || tracks[0].Source != "active" {
+		t.Errorf("tracks[0] = %s (%s), want alpha_20260101 (active)", tracks[0].TrackID, tracks[0].Source)
+	}
+	if !tracks[0].Phases[0].Tasks[0].Completed {
+		t.Error("alpha task One should be completed on feature")
+	}
+	if tracks[1].Source != "archived" {
+		t.Errorf("tracks[1].Source = %q, want archived", tracks[1].Source)
+	}
+
+	// The working tree must be untouched.
+	plan, err := os.ReadFile(filepath.Join(project, "conductor", "tracks", "alpha_20260101", "plan.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(plan), "[x]") {
+		t.Error("working tree plan.md should still be the main version")
+	}
+}
+
+func TestDiscoverTracks_BadRef(t *testing.T) {
+	_, project := testRepo(t)
+	if _, err := DiscoverTracks(project, "no-such-branch"); err == nil {
+		t.Error("expected error for unknown ref")
+	}
+}
+
+func TestCatFiles_MissingOmitted(t *testing.T) {
+	root, _ := testRepo(t)
+	got, err := CatFiles(root, []string{
+		"main:project/conductor/tracks/alpha_20260101/metadata.json",
+		"main:does/not/exist",
+	})
+	if err != nil {
+		t.Fatalf("CatFiles returned error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d blobs, want 1", len(got))
+	}
+	if !strings.Contains(got["main:project/conductor/tracks/alpha_20260101/metadata.json"], "alpha_20260101") {
+		t.Error("metadata blob content missing")
+	}
+}
+
+func TestBranchProgress_MostAdvanced(t *testing.T) {
+	root, project := testRepo(t)
+	gitCmd(t, root, "checkout", "-q", "-b", "feature")
+	writeTrack(t, project, "tracks", "alpha_20260101", "- [x] Task: One\n- [ ] Task: Two\n")
+	commitAll(t, root, "progress on feature")
+	gitCmd(t, root, "checkout", "-q", "main")
+
+	progress, err := BranchProgress(project)
+	if err != nil {
+		t.Fatalf("BranchProgress returned error: %v", err)
+	}
+	p, ok := progress["alpha_20260101"]
+	if !ok {
+		t.Fatal("missing progress for alpha_20260101")
+	}
+	if p.Branch != "feature" || p.Done != 1 || p.Total != 2 {
+		t.Errorf("progress = %+v, want feature 1/2", p)
+	}
+}
+
+func TestBranchProgress_TiePrefersCurrent(t *testing.T) {
+	root, project := testRepo(t)
+	gitCmd(t, root, "branch", "aaa-other")
+
+	progress, err := BranchProgress(project)
+	if err != nil {
+		t.Fatalf("BranchProgress returned error: %v", err)
+	}
+	if got := progress["alpha_20260101"].Branch; got != "main" {
+		t.Errorf("tie should go to current branch, got %q", got)
+	}
+}
+
+func TestWorktrees(t *testing.T) {
+	root, _ := testRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "wt-feature")
+	gitCmd(t, root, "worktree", "add", "-q", "-b", "feature", wtPath)
+
+	worktrees, err := Worktrees(root)
+	if err != nil {
+		t.Fatalf("Worktrees returned error: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("got %d worktrees, want 2", len(worktrees))
+	}
+	if worktrees[0].Branch != "main" {
+		t.Errorf("worktrees[0].Branch = %q, want main", worktrees[0].Branch)
+	}
+	if worktrees[1].Branch != "feature" || worktrees[1].Name() != "wt-feature" {
+		t.Errorf("worktrees[1] = %+v, want feature at wt-feature", worktrees[1])
+	}
+}
+
+func TestMergeWorktrees_FreshestWins(t *testing.T) {
+	root, project := testRepo(t)
+	wtPath := filepath.Join(t.TempDir(), "wt-feature")
+	gitCmd(t, root, "worktree", "add", "-q", "-b", "feature", wtPath)
+
+	// Progress alpha in the feature worktree and add a track only it has.
+	wtProject := filepath.Join(wtPath, "project")
+	writeTrack(t, wtProject, "tracks", "alpha_20260101", "- [x] Task: One\n- [~] Task: Two\n")
+	writeTrack(t, wtProject, "tracks", "gamma_20260301", "- [ ] Task: New\n")
+	past := time.Now().Add(-time.Hour)
+	for _, f := range []string{"metadata.json", "plan.md"} {
+		p := filepath.Join(project, "conductor", "tracks", "alpha_20260101", f)
+		if err := os.Chtimes(p, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tracks, origins, err := MergeWorktrees(project)
+	if err != nil {
+		t.Fatalf("MergeWorktrees returned error: %v", err)
+	}
+	if len(tracks) != 2 {
+		t.Fatalf("got %d tracks, want 2", len(tracks))
+	}
+	for _, tr := range tracks {
+		if origins[tr.TrackID].Worktree.Branch != "feature" {
+			t.Errorf("%s taken from %q, want feature", tr.TrackID, origins[tr.TrackID].Worktree.Branch)
+		}
+		if tr.TrackID == "alpha_20260101" && !tr.Phases[0].Tasks[0].Completed {
+			t.Error("alpha should carry the feature worktree's progress")
+		}
+	}
+	if origins["alpha_20260101"].BasePath != wtProject {
+		t.Errorf("origin BasePath = %q, want %q", origins["alpha_20260101"].BasePath, wtProject)
+	}
+}
+
+func TestBlame_AttributesCheckoff(t *testing.T) {
+	root, project := testRepo(t)
+	plan := filepath.Join(project, "conductor", "tracks", "alpha_20260101", "plan.md")
+	if err := os.WriteFile(plan, []byte("## Phase 1: Work\n\n- [x] Task: One\n- [ ] Task: Two\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitCmd(t, root, "add", "-A")
+	cmd := exec.Command("git", "commit", "-q", "-m", "check off one")
+	cmd.Dir = root
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Alice", "GIT_AUTHOR_EMAIL=alice@example.com",
+		"GIT_COMMITTER_NAME=Alice", "GIT_COMMITTER_EMAIL=alice@example.com",
+		"GIT_AUTHOR_DATE=2026-03-02T10:00:00Z",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("commit: %v\n%s", err, out)
+	}
+	// An uncommitted edit to line 4.
+	if err := os.WriteFile(plan, []byte("## Phase 1: Work\n\n- [x] Task: One\n- [~] Task: Two\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := Blame(plan)
+	if err != nil {
+		t.Fatalf("Blame returned error: %v", err)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4", len(lines))
+	}
+	one := lines[3]
+	if one.Author != "Alice" || one.AuthorEmail != "alice@example.com" || one.Summary != "check off one" {
+		t.Errorf("line 3 = %+v, want Alice's check-off commit", one)
+	}
+	if !one.AuthorTime.Equal(time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("AuthorTime = %v", one.AuthorTime)
+	}
+	if lines[1].Author != "Test" {
+		t.Errorf("line 1 author = %q, want Test (initial commit)", lines[1].Author)
+	}
+	if !lines[4].Uncommitted() {
+		t.Error("line 4 should be uncommitted")
+	}
+}
@@ -0,0 +1,239 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Refs lists local branches and remote-tracking refs by short name,
+// excluding symbolic remote HEADs such as "origin/HEAD".
+func Refs(dir string) ([]string, error) {
+	out, err := Run(dir, "for-each-ref", "--format=%(refname:short)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+	var refs []string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "HEAD" || strings.HasSuffix(line, "/HEAD") {
+			continue
+		}
+		refs = append(refs, line)
+	}
+	return refs, nil
+}
+
+// CurrentBranch returns the short name of the checked-out branch, or ""
+// when HEAD is detached.
+func CurrentBranch(dir string) string {
+	out, err := Run(dir, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// DiscoverTracks is the git counterpart of conductor.DiscoverTracks: it reads
+// conductor/tracks and conductor/archive as they exist at ref, using
+// git ls-tree and git cat-file so the working tree is never touched.
+// basePath is the project directory containing conductor/ and must be
+// inside a git repository.
+func DiscoverTracks(basePath, ref string) ([]conductor.Track, error) {
+	prefix, err := Run(basePath, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+	prefix = strings.TrimSpace(prefix)
+
+	dirs := []struct {
+		path   string
+		source string
+	}{
+		{path.Join(prefix, "conductor", "tracks"), "active"},
+		{path.Join(prefix, "conductor", "archive"), "archived"},
+	}
+
+	out, err := Run(basePath, "ls-tree", "-r", "--full-tree", "--name-only", ref, "--",
+		dirs[0].path+"/", dirs[1].path+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect <dir>/<track>/{metadata.json,plan.md} entries.
+	type entry struct {
+		source   string
+		id       string
+		metaPath string
+		planPath string
+	}
+	var order []string
+	entries := map[string]*entry{}
+	for _, file := range strings.Split(out, "\n") {
+		for _, d := range dirs {
+			rest, ok := strings.CutPrefix(file, d.path+"/")
+			if !ok {
+				continue
+			}
+			id, name, ok := strings.Cut(rest, "/")
+			if !ok || strings.Contains(name, "/") {
+				continue
+			}
+			key := d.source + "/" + id
+			e, ok := entries[key]
+			if !ok {
+				e = &entry{source: d.source, id: id}
+				entries[key] = e
+				order = append(order, key)
+			}
+			switch name {
+			case "metadata.json":
+				e.metaPath = file
+			case "plan.md":
+				e.planPath = file
+			}
+		}
+	}
+
+	var specs []string
+	for _, key := range order {
+		e := entries[key]
+		if e.metaPath != "" {
+			specs = append(specs, ref+":"+e.metaPath)
+		}
+		if e.planPath != "" {
+			specs = append(specs, ref+":"+e.planPath)
+		}
+	}
+	blobs, err := CatFiles(basePath, specs)
+	if err != nil {
+		return nil, err
+	}
+
+	var tracks []conductor.Track
+	for _, key := range order {
+		e := entries[key]
+		meta, ok := blobs[ref+":"+e.metaPath]
+		if e.metaPath == "" || !ok {
+			continue
+		}
+		track, err := conductor.LoadMetadata([]byte(meta))
+		if err != nil {
+			continue
+		}
+		if track.TrackID == "" {
+			track.TrackID = e.id
+		}
+		track.Source = e.source
+		if plan, ok := blobs[ref+":"+e.planPath]; ok && e.planPath != "" {
+			track.Phases = conductor.ParsePlan(plan)
+		}
+		tracks = append(tracks, track)
+	}
+	return conductor.SortTracks(tracks), nil
+}
+
+// CatFiles reads many "rev:path" objects through a single
+// git cat-file --batch process. Missing objects are omitted from the
+// result rather than reported as errors.
+func CatFiles(dir string, specs []string) (map[string]string, error) {
+	result := map[string]string{}
+	if len(specs) == 0 {
+		return result, nil
+	}
+
+	cmd := exec.Command("git", "cat-file", "--batch")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(strings.Join(specs, "\n") + "\n")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+
+	r := bufio.NewReader(stdout)
+	for _, spec := range specs {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			cmd.Wait()
+			return nil, fmt.Errorf("git cat-file: %w", err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			continue // "<spec> missing"
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			cmd.Wait()
+			return nil, fmt.Errorf("git cat-file: bad header %q", header)
+		}
+		buf := make([]byte, size+1) // content plus trailing newline
+		if _, err := io.ReadFull(r, buf); err != nil {
+			cmd.Wait()
+			return nil, fmt.Errorf("git cat-file: %w", err)
+		}
+		result[spec] = string(buf[:size])
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git cat-file: %w", err)
+	}
+	return result, nil
+}
+
+// Progress summarizes a track's task completion on a particular branch.
+type Progress struct {
+	Branch string
+	Done   int
+	Total  int
+}
+
+// BranchProgress reads the tracks on every ref and returns, per track ID,
+// the branch on which the track is most advanced (most completed tasks).
+// Ties go to the current branch, then to the first ref in listing order.
+func BranchProgress(basePath string) (map[string]Progress, error) {
+	refs, err := Refs(basePath)
+	if err != nil {
+		return nil, err
+	}
+	current := CurrentBranch(basePath)
+	if current != "" {
+		// Move the current branch to the front so it wins ties.
+		for i, r := range refs {
+			if r == current {
+				refs = append([]string{current}, append(refs[:i:i], refs[i+1:]...)...)
+				break
+			}
+		}
+	}
+
+	best := map[string]Progress{}
+	for _, ref := range refs {
+		tracks, err := DiscoverTracks(basePath, ref)
+		if err != nil {
+			continue
+		}
+		for _, t := range tracks {
+			p := Progress{Branch: ref}
+			for _, ph := range t.Phases {
+				for _, task := range ph.Tasks {
+					p.Total++
+					if task.Completed {
+						p.Done++
+					}
+				}
+			}
+			if prev, ok := best[t.TrackID]; !ok || p.Done > prev.Done {
+				best[t.TrackID] = p
+			}
+		}
+	}
+	return best, nil
+}
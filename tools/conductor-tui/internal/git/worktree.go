@@ -0,0 +1,112 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Worktree is one entry of git worktree list.
+type Worktree struct {
+	Path   string
+	Head   string
+	Branch string // short branch name, empty when detached
+}
+
+// Name returns a short label for the worktree: its directory name.
+func (w Worktree) Name() string {
+	return filepath.Base(w.Path)
+}
+
+// Worktrees lists the repository's worktrees, main worktree first.
+// Bare and prunable entries are skipped.
+func Worktrees(dir string) ([]Worktree, error) {
+	out, err := Run(dir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(out), "\n\n") {
+		var w Worktree
+		skip := false
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				w.Path = value
+			case "HEAD":
+				w.Head = value
+			case "branch":
+				w.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare", "prunable":
+				skip = true
+			}
+		}
+		if w.Path != "" && !skip {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+// TrackOrigin records which worktree supplied a track in the merged view.
+type TrackOrigin struct {
+	Worktree Worktree
+	BasePath string    // project directory (containing conductor/) in that worktree
+	Modified time.Time // latest mtime of the track's metadata.json and plan.md
+}
+
+// MergeWorktrees discovers tracks in every worktree and returns a merged
+// list holding, for each track ID, the state from the worktree where the
+// track's files were modified most recently, together with the origin of
+// each track. basePath is the project directory in the current worktree;
+// the same relative directory is used in every other worktree.
+func MergeWorktrees(basePath string) ([]conductor.Track, map[string]TrackOrigin, error) {
+	prefix, err := Run(basePath, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, nil, err
+	}
+	prefix = strings.TrimSpace(prefix)
+
+	worktrees, err := Worktrees(basePath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged := map[string]conductor.Track{}
+	origins := map[string]TrackOrigin{}
+	for _, wt := range worktrees {
+		project := filepath.Join(wt.Path, filepath.FromSlash(prefix))
+		for _, t := range conductor.DiscoverTracks(project) {
+			dir := conductor.TrackDir(project, t)
+			mod := latestModTime(filepath.Join(dir, "metadata.json"), filepath.Join(dir, "plan.md"))
+			if prev, ok := origins[t.TrackID]; ok && !mod.After(prev.Modified) {
+				continue
+			}
+			merged[t.TrackID] = t
+			origins[t.TrackID] = TrackOrigin{Worktree: wt, BasePath: project, Modified: mod}
+		}
+	}
+
+	tracks := make([]conductor.Track, 0, len(merged))
+	for _, t := range merged {
+		tracks = append(tracks, t)
+	}
+	return conductor.SortTracks(tracks), origins, nil
+}
+
+// latestModTime returns the newest modification time among paths,
+// ignoring files that do not exist.
+func latestModTime(paths ...string) time.Time {
+	var latest time.Time
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil && info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
@@ -0,0 +1,124 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+)
+
+// RenderBurndown draws the remaining-task series as a bar chart using
+// block characters. The chart body is width columns by height rows,
+// followed by a date axis. Each column shows the state at the last
+// revision on or before its point in time.
+func RenderBurndown(points []Point, width, height int) string {
+	if len(points) == 0 {
+		return "No history."
+	}
+	if width < 10 {
+		width = 10
+	}
+	if height < 2 {
+		height = 2
+	}
+
+	max := 0
+	for _, p := range points {
+		if p.Total > max {
+			max = p.Total
+		}
+	}
+	if max == 0 {
+		return "No tasks."
+	}
+
+	labelW := len(fmt.Sprintf("%d", max)) + 1
+	cols := width - labelW - 1
+	if cols < 1 {
+		cols = 1
+	}
+
+	first, last := points[0].Time, points[len(points)-1].Time
+	span := last.Sub(first)
+	values := make([]int, cols)
+	idx := 0
+	for c := 0; c < cols; c++ {
+		at := first
+		if cols > 1 {
+			at = first.Add(span * time.Duration(c) / time.Duration(cols-1))
+		}
+		for idx+1 < len(points) && !points[idx+1].Time.After(at) {
+			idx++
+		}
+		values[c] = points[idx].Remaining
+	}
+
+	var b strings.Builder
+	for row := height; row >= 1; row-- {
+		label := ""
+		if row == height {
+			label = fmt.Sprintf("%d", max)
+		} else if row == 1 {
+			label = "0"
+		}
+		b.WriteString(util.Spaces(labelW-len(label)) + label + "│")
+		for _, v := range values {
+			// Scale to half-rows so the top of a bar can be a half block.
+			halves := (v*height*2 + max - 1) / max
+			switch {
+			case halves >= row*2:
+				b.WriteString("█")
+			case halves == row*2-1:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(util.Spaces(labelW) + "└" + strings.Repeat("─", cols) + "\n")
+
+	from := first.Format("2006-01-02")
+	to := last.Format("2006-01-02")
+	gap := cols - len(from) - len(to)
+	if gap < 1 {
+		gap = 1
+	}
+	b.WriteString(util.Spaces(labelW+1) + from + util.Spaces(gap) + to)
+	return b.String()
+}
+
+// RenderThroughput renders weekly throughput as a fixed-width table.
+func RenderThroughput(weeks []Week) string {
+	if len(weeks) == 0 {
+		return "No completed tasks."
+	}
+	var b strings.Builder
+	b.WriteString(util.Pad("Week", 14) + util.Pad("Done", 8) + "Mean cycle\n")
+	for i, w := range weeks {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		mean := "—"
+		if w.MeanCycle > 0 {
+			mean = FormatDuration(w.MeanCycle)
+		}
+		b.WriteString(util.Pad(w.Start.Format("2006-01-02"), 14) +
+			util.Pad(fmt.Sprintf("%d", w.Completed), 8) + mean)
+	}
+	return b.String()
+}
+
+// FormatDuration renders d in the largest sensible unit, e.g. "3d 4h",
+// "5h 12m" or "45m".
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
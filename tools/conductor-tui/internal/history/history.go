@@ -0,0 +1,235 @@
+// Package history derives delivery metrics for a track from the git history
+// of its plan.md: per-task cycle times, a burndown series and weekly
+// throughput.
+package history
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/git"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Revision is a parsed plan.md as of a single commit.
+type Revision struct {
+	Commit string
+	Time   time.Time
+	Phases []conductor.Phase
+}
+
+// TaskCycle records when a task was started ([~]) and completed ([x]).
+// Started is zero when the task went straight to [x] without passing
+// through [~]; Done is zero when the task is not completed yet.
+type TaskCycle struct {
+	Phase       int
+	Task        string
+	StartCommit string
+	Started     time.Time
+	DoneCommit  string
+	Done        time.Time
+}
+
+// CycleTime returns the time between start and completion, or zero if
+// either end is unknown.
+func (c TaskCycle) CycleTime() time.Duration {
+	if c.Started.IsZero() || c.Done.IsZero() {
+		return 0
+	}
+	return c.Done.Sub(c.Started)
+}
+
+// Point is a single sample of the burndown series.
+type Point struct {
+	Time      time.Time
+	Total     int
+	Remaining int
+}
+
+// Week aggregates the tasks completed in one ISO week.
+type Week struct {
+	Start     time.Time // Monday 00:00 UTC
+	Completed int
+	MeanCycle time.Duration // mean over tasks with a known cycle time
+}
+
+// Report is the full set of metrics for one plan.
+type Report struct {
+	Cycles     []TaskCycle
+	Burndown   []Point
+	Throughput []Week
+}
+
+// Analyze reads the history of planPath and computes its Report.
+func Analyze(planPath string) (Report, error) {
+	revs, err := PlanRevisions(planPath)
+	if err != nil {
+		return Report{}, err
+	}
+	cycles := TaskCycles(revs)
+	return Report{
+		Cycles:     cycles,
+		Burndown:   Burndown(revs),
+		Throughput: Throughput(cycles),
+	}, nil
+}
+
+// PlanRevisions returns every committed version of planPath, oldest first.
+// Renames (e.g. a track moving to conductor/archive) are followed.
+func PlanRevisions(planPath string) ([]Revision, error) {
+	dir := filepath.Dir(planPath)
+	root, err := git.Root(dir)
+	if err != nil {
+		return nil, err
+	}
+	out, err := git.Run(dir, "log", "--follow", "--name-only",
+		"--format=%x1e%H%x09%cI", "--", filepath.Base(planPath))
+	if err != nil {
+		return nil, err
+	}
+
+	var revs []Revision
+	for _, rec := range strings.Split(out, "\x1e") {
+		lines := strings.Split(strings.TrimSpace(rec), "\n")
+		if len(lines) < 2 {
+			continue
+		}
+		header := strings.SplitN(lines[0], "\t", 2)
+		if len(header) != 2 {
+			continue
+		}
+		when, err := time.Parse(time.RFC3339, header[1])
+		if err != nil {
+			return nil, fmt.Errorf("bad commit date %q: %w", header[1], err)
+		}
+		path := strings.TrimSpace(lines[len(lines)-1])
+		content, err := git.Show(root, header[0], path)
+		if err != nil {
+			continue // file deleted in this commit
+		}
+		revs = append(revs, Revision{
+			Commit: header[0],
+			Time:   when,
+			Phases: conductor.ParsePlan(content),
+		})
+	}
+
+	// git log lists newest first; --reverse cannot be combined with --follow.
+	for i, j := 0, len(revs)-1; i < j; i, j = i+1, j-1 {
+		revs[i], revs[j] = revs[j], revs[i]
+	}
+	return revs, nil
+}
+
+// taskKey identifies a task across revisions by phase number and name.
+type taskKey struct {
+	phase int
+	name  string
+}
+
+// TaskCycles walks the revisions in order and records, for every task in
+// the latest revision, the commit where it changed to [~] and the commit
+// where it changed to [x]. A task that is reopened loses its completion.
+func TaskCycles(revs []Revision) []TaskCycle {
+	cycles := map[taskKey]*TaskCycle{}
+	for _, rev := range revs {
+		for _, p := range rev.Phases {
+			for _, t := range p.Tasks {
+				k := taskKey{p.Number, t.Name}
+				c, ok := cycles[k]
+				if !ok {
+					c = &TaskCycle{Phase: p.Number, Task: t.Name}
+					cycles[k] = c
+				}
+				switch {
+				case t.InProgress:
+					if c.Started.IsZero() || !c.Done.IsZero() {
+						c.StartCommit, c.Started = rev.Commit, rev.Time
+					}
+					c.DoneCommit, c.Done = "", time.Time{}
+				case t.Completed:
+					if c.Done.IsZero() {
+						c.DoneCommit, c.Done = rev.Commit, rev.Time
+					}
+				default:
+					*c = TaskCycle{Phase: p.Number, Task: t.Name}
+				}
+			}
+		}
+	}
+
+	if len(revs) == 0 {
+		return nil
+	}
+	var result []TaskCycle
+	for _, p := range revs[len(revs)-1].Phases {
+		for _, t := range p.Tasks {
+			if c, ok := cycles[taskKey{p.Number, t.Name}]; ok {
+				result = append(result, *c)
+			}
+		}
+	}
+	return result
+}
+
+// Burndown returns the total and remaining task counts at every revision.
+func Burndown(revs []Revision) []Point {
+	points := make([]Point, 0, len(revs))
+	for _, rev := range revs {
+		total, done := 0, 0
+		for _, p := range rev.Phases {
+			for _, t := range p.Tasks {
+				total++
+				if t.Completed {
+					done++
+				}
+			}
+		}
+		points = append(points, Point{Time: rev.Time, Total: total, Remaining: total - done})
+	}
+	return points
+}
+
+// Throughput groups completed tasks by the week they were completed in.
+func Throughput(cycles []TaskCycle) []Week {
+	weeks := map[time.Time]*Week{}
+	sums := map[time.Time]time.Duration{}
+	counted := map[time.Time]int{}
+	for _, c := range cycles {
+		if c.Done.IsZero() {
+			continue
+		}
+		start := WeekStart(c.Done)
+		w, ok := weeks[start]
+		if !ok {
+			w = &Week{Start: start}
+			weeks[start] = w
+		}
+		w.Completed++
+		if d := c.CycleTime(); d > 0 {
+			sums[start] += d
+			counted[start]++
+		}
+	}
+
+	result := make([]Week, 0, len(weeks))
+	for start, w := range weeks {
+		if counted[start] > 0 {
+			w.MeanCycle = sums[start] / time.Duration(counted[start])
+		}
+		result = append(result, *w)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Start.Before(result[j].Start) })
+	return result
+}
+
+// WeekStart returns Monday 00:00 UTC of the week containing t.
+func WeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,187 @@
+package history
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// testRepo creates a temporary git repository for history tests.
+func testRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	gitCmd(t, dir, time.Time{}, "init", "-q")
+	return dir
+}
+
+// gitCmd runs git in dir with a fixed identity and commit date.
+func gitCmd(t *testing.T, dir string, when time.Time, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if !when.IsZero() {
+		date := when.Format(time.RFC3339)
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+// commitPlan writes plan.md content and commits it at the given time.
+func commitPlan(t *testing.T, dir, content string, when time.Time) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "plan.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitCmd(t, dir, when, "add", "plan.md")
+	gitCmd(t, dir, when, "commit", "-q", "-m", "update plan")
+}
+
+const planV1 = `## Phase 1: Setup
+
+- [ ] Task: First
+- [ ] Task: Second
+`
+
+const planV2 = `## Phase 1: Setup
+
+- [~] Task: First
+- [ ] Task: Second
+`
+
+const planV3 = `## Phase 1: Setup
+
+- [x] Task: First ` + "`abc1234`" + `
+- [x] Task: Second
+`
+
+func TestAnalyze_CycleTimes(t *testing.T) {
+	dir := testRepo(t)
+	t0 := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC) // a Monday
+	commitPlan(t, dir, planV1, t0)
+	commitPlan(t, dir, planV2, t0.Add(2*time.Hour))
+	commitPlan(t, dir, planV3, t0.Add(26*time.Hour))
+
+	report, err := Analyze(filepath.Join(dir, "plan.md"))
+	if err != nil {
+		t.Fatalf("Analyze returned error: %v", err)
+	}
+
+	if len(report.Cycles) != 2 {
+		t.Fatalf("got %d cycles, want 2", len(report.Cycles))
+	}
+	first := report.Cycles[0]
+	if first.Task != "First" {
+		t.Errorf("Cycles[0].Task = %q, want %q", first.Task, "First")
+	}
+	if got := first.CycleTime(); got != 24*time.Hour {
+		t.Errorf("First cycle time = %v, want 24h", got)
+	}
+	second := report.Cycles[1]
+	if !second.Started.IsZero() {
+		t.Errorf("Second should have no start, got %v", second.Started)
+	}
+	if second.Done.IsZero() {
+		t.Error("Second should be done")
+	}
+	if second.CycleTime() != 0 {
+		t.Errorf("Second cycle time = %v, want 0 (unknown)", second.CycleTime())
+	}
+
+	if len(report.Burndown) != 3 {
+		t.Fatalf("got %d burndown points, want 3", len(report.Burndown))
+	}
+	if report.Burndown[0].Remaining != 2 || report.Burndown[2].Remaining != 0 {
+		t.Errorf("burndown remaining = %d..%d, want 2..0",
+			report.Burndown[0].Remaining, report.Burndown[2].Remaining)
+	}
+
+	if len(report.Throughput) != 1 {
+		t.Fatalf("got %d weeks, want 1", len(report.Throughput))
+	}
+	if report.Throughput[0].Completed != 2 {
+		t.Errorf("week completed = %d, want 2", report.Throughput[0].Completed)
+	}
+	if report.Throughput[0].MeanCycle != 24*time.Hour {
+		t.Errorf("week mean cycle = %v, want 24h", report.Throughput[0].MeanCycle)
+	}
+}
+
+func TestAnalyze_NotARepo(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Analyze(filepath.Join(dir, "plan.md")); err == nil {
+		t.Error("expected error outside a git repository, got nil")
+	}
+}
+
+func TestTaskCycles_Reopened(t *testing.T) {
+	t0 := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	revs := []Revision{
+		{Commit: "a", Time: t0, Phases: conductor.ParsePlan(planV2)},
+		{Commit: "b", Time: t0.Add(time.Hour), Phases: conductor.ParsePlan(planV3)},
+		{Commit: "c", Time: t0.Add(2 * time.Hour), Phases: conductor.ParsePlan(planV1)},
+	}
+	cycles := TaskCycles(revs)
+	if len(cycles) != 2 {
+		t.Fatalf("got %d cycles, want 2", len(cycles))
+	}
+	if !cycles[0].Done.IsZero() || !cycles[0].Started.IsZero() {
+		t.Errorf("reopened task should have no start or completion, got %+v", cycles[0])
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	sunday := time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	if got := WeekStart(sunday); !got.Equal(want) {
+		t.Errorf("WeekStart(%v) = %v, want %v", sunday, got, want)
+	}
+}
+
+func TestRenderBurndown(t *testing.T) {
+	t0 := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	points := []Point{
+		{Time: t0, Total: 4, Remaining: 4},
+		{Time: t0.Add(48 * time.Hour), Total: 4, Remaining: 2},
+		{Time: t0.Add(96 * time.Hour), Total: 4, Remaining: 0},
+	}
+	out := RenderBurndown(points, 30, 4)
+	if !strings.Contains(out, "█") {
+		t.Error("chart should contain full blocks")
+	}
+	if !strings.Contains(out, "2026-03-02") || !strings.Contains(out, "2026-03-06") {
+		t.Errorf("chart should contain the date range, got:\n%s", out)
+	}
+	if RenderBurndown(nil, 30, 4) != "No history." {
+		t.Error("empty series should render placeholder")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{45 * time.Minute, "45m"},
+		{5*time.Hour + 12*time.Minute, "5h 12m"},
+		{76 * time.Hour, "3d 4h"},
+	}
+	for _, tt := range tests {
+		if got := FormatDuration(tt.d); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
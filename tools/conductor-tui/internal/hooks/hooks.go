@@ -0,0 +1,150 @@
+// Package hooks runs the shell commands configured for change events.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+)
+
+// maxOutput caps the captured output kept per run.
+const maxOutput = 64 << 10
+
+// Result is the outcome of one hook run.
+type Result struct {
+	Hook     config.Hook
+	Event    events.Event
+	Started  time.Time
+	Duration time.Duration
+	Output   string
+	ExitCode int   // -1 when the command could not run or was killed
+	Err      error // set on start failure or timeout
+}
+
+// Runner executes hook commands in Dir with a per-command timeout and a
+// limit on how many run at once. It is safe for concurrent use.
+type Runner struct {
+	Dir      string
+	Commands []config.Hook
+	Timeout  time.Duration
+	sem      chan struct{}
+}
+
+// NewRunner returns a Runner for the hooks configuration, running
+// commands from dir (the project root).
+func NewRunner(dir string, cfg config.Hooks) *Runner {
+	return &Runner{
+		Dir:      dir,
+		Commands: cfg.Commands,
+		Timeout:  time.Duration(cfg.Timeout),
+		sem:      make(chan struct{}, max(cfg.Concurrency, 1)),
+	}
+}
+
+// Job is a hook paired with the event that triggered it.
+type Job struct {
+	Hook  config.Hook
+	Event events.Event
+}
+
+// Jobs returns a job for every configured hook matching each event, in
+// event order and then config order.
+func (r *Runner) Jobs(evs []events.Event) []Job {
+	var jobs []Job
+	for _, e := range evs {
+		for _, h := range r.Commands {
+			if h.Matches(e) {
+				jobs = append(jobs, Job{Hook: h, Event: e})
+			}
+		}
+	}
+	return jobs
+}
+
+// Run executes a job, waiting for a free slot first. The event is passed
+// as JSON on stdin and as CONDUCTOR_* environment variables.
+func (r *Runner) Run(ctx context.Context, job Job) Result {
+	res := Result{Hook: job.Hook, Event: job.Event, ExitCode: -1}
+	select {
+	case r.sem <- struct{}{}:
+		defer func() { <-r.sem }()
+	case <-ctx.Done():
+		res.Err = ctx.Err()
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", job.Hook.Run)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", job.Hook.Run)
+	}
+	cmd.Dir = r.Dir
+	cmd.Env = append(os.Environ(), Env(job.Event)...)
+	payload, _ := json.Marshal(job.Event)
+	cmd.Stdin = bytes.NewReader(payload)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Do not wait forever on pipes held open by orphaned children.
+	cmd.WaitDelay = time.Second
+
+	res.Started = time.Now()
+	err := cmd.Run()
+	res.Duration = time.Since(res.Started)
+	res.Output = out.String()
+	if len(res.Output) > maxOutput {
+		res.Output = res.Output[len(res.Output)-maxOutput:]
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		res.Err = errors.New("timed out after " + r.Timeout.String())
+	case err == nil:
+		res.ExitCode = 0
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	default:
+		res.Err = err
+	}
+	return res
+}
+
+// Env returns the CONDUCTOR_* environment variables describing e.
+func Env(e events.Event) []string {
+	env := []string{
+		"CONDUCTOR_EVENT=" + e.Type,
+		"CONDUCTOR_TIME=" + e.Time.Format(time.RFC3339),
+		"CONDUCTOR_TRACK_ID=" + e.TrackID,
+		"CONDUCTOR_TRACK_DESCRIPTION=" + e.Description,
+		"CONDUCTOR_PHASE_NAME=" + e.PhaseName,
+		"CONDUCTOR_TASK=" + e.Task,
+		"CONDUCTOR_FROM=" + e.From,
+		"CONDUCTOR_TO=" + e.To,
+		"CONDUCTOR_COMMIT=" + e.Commit,
+	}
+	if e.Phase > 0 {
+		env = append(env, "CONDUCTOR_PHASE="+strconv.Itoa(e.Phase))
+	} else {
+		env = append(env, "CONDUCTOR_PHASE=")
+	}
+	return env
+}
+
+// Failed reports whether the run did not exit cleanly.
+func (r Result) Failed() bool {
+	return r.Err != nil || r.ExitCode != 0
+}
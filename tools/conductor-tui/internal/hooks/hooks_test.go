@@ -0,0 +1,106 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+)
+
+func newRunner(t *testing.T, timeout time.Duration, concurrency int, hooks ...config.Hook) *Runner {
+	t.Helper()
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	return NewRunner(t.TempDir(), config.Hooks{
+		Timeout:     config.Duration(timeout),
+		Concurrency: concurrency,
+		Commands:    hooks,
+	})
+}
+
+var completed = events.Event{
+	Type: events.TaskCompleted, TrackID: "auth_20260301", Phase: 2, PhaseName: "Build",
+	Task: "Add login", Commit: "abc1234", Time: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+}
+
+func TestJobs_MatchesInOrder(t *testing.T) {
+	r := newRunner(t, time.Second, 1,
+		config.Hook{On: events.TaskCompleted, Run: "a"},
+		config.Hook{On: events.TaskStarted, Run: "b"},
+		config.Hook{On: "*", Run: "c"},
+	)
+	jobs := r.Jobs([]events.Event{completed})
+	if len(jobs) != 2 || jobs[0].Hook.Run != "a" || jobs[1].Hook.Run != "c" {
+		t.Errorf("jobs = %+v", jobs)
+	}
+}
+
+func TestRun_EnvAndStdin(t *testing.T) {
+	r := newRunner(t, 5*time.Second, 1)
+	res := r.Run(context.Background(), Job{
+		Hook:  config.Hook{Run: `echo "$CONDUCTOR_EVENT $CONDUCTOR_TRACK_ID $CONDUCTOR_PHASE $CONDUCTOR_TASK"; cat`},
+		Event: completed,
+	})
+	if res.Failed() {
+		t.Fatalf("hook failed: %+v", res)
+	}
+	first, rest, _ := strings.Cut(res.Output, "\n")
+	if first != "task_completed auth_20260301 2 Add login" {
+		t.Errorf("env line = %q", first)
+	}
+	var got events.Event
+	if err := json.Unmarshal([]byte(rest), &got); err != nil || got.Commit != "abc1234" {
+		t.Errorf("stdin JSON = %q (%v)", rest, err)
+	}
+}
+
+func TestRun_ExitCode(t *testing.T) {
+	r := newRunner(t, 5*time.Second, 1)
+	res := r.Run(context.Background(), Job{Hook: config.Hook{Run: "echo boom >&2; exit 3"}, Event: completed})
+	if res.ExitCode != 3 || res.Err != nil || !res.Failed() {
+		t.Errorf("result = %+v, want exit 3", res)
+	}
+	if strings.TrimSpace(res.Output) != "boom" {
+		t.Errorf("stderr should be captured, got %q", res.Output)
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	r := newRunner(t, 100*time.Millisecond, 1)
+	start := time.Now()
+	res := r.Run(context.Background(), Job{Hook: config.Hook{Run: "sleep 5"}, Event: completed})
+	if res.Err == nil || !strings.Contains(res.Err.Error(), "timed out") {
+		t.Errorf("err = %v, want timeout", res.Err)
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Error("timeout did not stop the command promptly")
+	}
+}
+
+func TestRun_ConcurrencyLimit(t *testing.T) {
+	r := newRunner(t, 5*time.Second, 1)
+	var wg sync.WaitGroup
+	results := make([]Result, 2)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.Run(context.Background(), Job{Hook: config.Hook{Run: "sleep 0.2"}, Event: completed})
+		}()
+	}
+	wg.Wait()
+	a, b := results[0], results[1]
+	if a.Started.After(b.Started) {
+		a, b = b, a
+	}
+	if b.Started.Before(a.Started.Add(a.Duration)) {
+		t.Error("runs overlapped despite a concurrency limit of 1")
+	}
+}
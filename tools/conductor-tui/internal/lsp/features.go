@@ -0,0 +1,397 @@
+package lsp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/check"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/git"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"` // UTF-16 code units
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type textDocumentPositionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     position               `json:"position"`
+}
+
+type codeActionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Range        lspRange               `json:"range"`
+}
+
+type diagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Code     string   `json:"code,omitempty"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+type textEdit struct {
+	Range   lspRange `json:"range"`
+	NewText string   `json:"newText"`
+}
+
+type codeAction struct {
+	Title string `json:"title"`
+	Kind  string `json:"kind"`
+	Edit  struct {
+		Changes map[string][]textEdit `json:"changes"`
+	} `json:"edit"`
+}
+
+type documentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail,omitempty"`
+	Kind           int              `json:"kind"`
+	Range          lspRange         `json:"range"`
+	SelectionRange lspRange         `json:"selectionRange"`
+	Children       []documentSymbol `json:"children,omitempty"`
+}
+
+// Diagnostic severities.
+const (
+	severityError   = 1
+	severityWarning = 2
+	severityInfo    = 3
+)
+
+// Code action kinds.
+const (
+	actionRewrite  = "refactor.rewrite"
+	actionRefactor = "refactor"
+)
+
+// Symbol kinds used for the outline: phases are modules, tasks functions
+// and sub-tasks fields.
+const (
+	symbolModule   = 2
+	symbolField    = 8
+	symbolFunction = 12
+)
+
+// lines splits text into lines without their line terminators.
+func lines(text string) []string {
+	ls := strings.Split(text, "\n")
+	for i, l := range ls {
+		ls[i] = strings.TrimSuffix(l, "\r")
+	}
+	return ls
+}
+
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// utf16Offset converts a byte offset within s to UTF-16 code units.
+func utf16Offset(s string, b int) int {
+	return utf16Len(s[:b])
+}
+
+// lineRange spans a whole 0-based line of ls.
+func lineRange(ls []string, line int) lspRange {
+	end := 0
+	if line < len(ls) {
+		end = utf16Len(ls[line])
+	}
+	return lspRange{Start: position{Line: line}, End: position{Line: line, Character: end}}
+}
+
+// trackContext locates the track holding a plan.md or metadata.json at
+// path: basePath is the project root and the track is loaded from the open
+// buffers, falling back to disk.
+func (s *Server) trackContext(path string) (basePath string, t conductor.Track, ok bool) {
+	dir := filepath.Dir(path)
+	parent := filepath.Dir(dir)
+	root := filepath.Dir(parent)
+	if filepath.Base(root) != "conductor" {
+		return "", conductor.Track{}, false
+	}
+	switch filepath.Base(parent) {
+	case "tracks":
+		t.Source = "active"
+	case "archive":
+		t.Source = "archived"
+	default:
+		return "", conductor.Track{}, false
+	}
+
+	if meta, ok := s.read(filepath.Join(dir, "metadata.json")); ok {
+		if loaded, err := conductor.LoadMetadata([]byte(meta)); err == nil {
+			loaded.Source = t.Source
+			t = loaded
+		}
+	}
+	// Checks resolve files by track ID, so use the directory name.
+	t.TrackID = filepath.Base(dir)
+	if plan, ok := s.read(filepath.Join(dir, "plan.md")); ok {
+		t.Phases = conductor.ParsePlan(plan)
+	}
+	return filepath.Dir(root), t, true
+}
+
+// read returns the open buffer for path, or the file on disk.
+func (s *Server) read(path string) (string, bool) {
+	if doc, ok := s.docs[path]; ok {
+		return doc.text, true
+	}
+	raw, err := os.ReadFile(path)
+	return string(raw), err == nil
+}
+
+// diagnostics validates a plan.md or metadata.json and, inside a track,
+// applies the `conductor-tui check` rules.
+func (s *Server) diagnostics(path, text string) []diagnostic {
+	out := []diagnostic{}
+	ls := lines(text)
+	basePath, t, ok := s.trackContext(path)
+	var problems []conductor.Problem
+	switch filepath.Base(path) {
+	case "plan.md":
+		problems = conductor.ValidatePlan(text)
+	case "metadata.json":
+		problems = conductor.ValidateMetadata([]byte(text))
+		if ok {
+			// The project's conductor/tui.json may define its own statuses and types.
+			cfg, _ := config.Load(basePath)
+			problems = conductor.ValidateMetadataFor([]byte(text), cfg.Statuses.Values(), cfg.Types.Values())
+		}
+	default:
+		return out
+	}
+	for _, p := range problems {
+		sev := map[string]int{conductor.ProblemError: severityError, conductor.ProblemWarning: severityWarning}[p.Severity]
+		if sev == 0 {
+			sev = severityInfo
+		}
+		out = append(out, diagnostic{Range: lineRange(ls, max(p.Line-1, 0)), Severity: sev, Source: "conductor", Message: p.Message})
+	}
+
+	if !ok {
+		return out
+	}
+	if filepath.Base(path) == "metadata.json" {
+		if meta, err := conductor.LoadMetadata([]byte(text)); err == nil && meta.TrackID != "" && meta.TrackID != t.TrackID {
+			out = append(out, diagnostic{
+				Range: lineRange(ls, 0), Severity: severityWarning, Source: "conductor",
+				Message: fmt.Sprintf("track_id %q does not match the directory name %q", meta.TrackID, t.TrackID),
+			})
+		}
+	}
+	rel := filepath.ToSlash(path)
+	if r, err := filepath.Rel(basePath, path); err == nil {
+		rel = filepath.ToSlash(r)
+	}
+	for _, v := range check.Run(basePath, []conductor.Track{t}, check.Config{}) {
+		if v.Path != rel {
+			continue
+		}
+		sev := severityError
+		if v.Severity == check.SeverityWarning {
+			sev = severityWarning
+		}
+		out = append(out, diagnostic{
+			Range: lineRange(ls, max(v.Line-1, 0)), Severity: sev, Code: v.Rule, Source: "conductor-check", Message: v.Message,
+		})
+	}
+	return out
+}
+
+// hover describes the commit behind a task SHA or phase checkpoint.
+func (s *Server) hover(p textDocumentPositionParams) any {
+	path := uriToPath(p.TextDocument.URI)
+	text, ok := s.read(path)
+	if !ok || filepath.Base(path) != "plan.md" {
+		return nil
+	}
+	ls := lines(text)
+	if p.Position.Line >= len(ls) {
+		return nil
+	}
+	line := ls[p.Position.Line]
+
+	sha := ""
+	if m := conductor.TaskRe.FindStringSubmatch(line); m != nil {
+		sha = m[3]
+	} else if m := conductor.PhaseRe.FindStringSubmatch(line); m != nil {
+		sha = m[3]
+	}
+	if sha == "" {
+		return nil
+	}
+	start := strings.LastIndex(line, sha)
+	r := lspRange{
+		Start: position{Line: p.Position.Line, Character: utf16Offset(line, start)},
+		End:   position{Line: p.Position.Line, Character: utf16Offset(line, start+len(sha))},
+	}
+	if p.Position.Character < r.Start.Character || p.Position.Character > r.End.Character {
+		return nil
+	}
+
+	var md string
+	if c, err := git.LookupCommit(filepath.Dir(path), sha); err != nil {
+		md = fmt.Sprintf("Commit `%s` was not found in this repository.", sha)
+	} else {
+		md = fmt.Sprintf("**%s** %s\n\n%s <%s>, %s", c.SHA[:min(len(c.SHA), 12)], c.Subject,
+			c.Author, c.AuthorEmail, c.AuthorTime.Local().Format("2006-01-02 15:04"))
+		if c.Body != "" {
+			md += "\n\n" + c.Body
+		}
+	}
+	return map[string]any{
+		"contents": map[string]any{"kind": "markdown", "value": md},
+		"range":    r,
+	}
+}
+
+// codeActions offers checkbox changes for the task or sub-task under the
+// cursor and a template for a new phase at the end of the plan.
+func (s *Server) codeActions(p codeActionParams) []codeAction {
+	out := []codeAction{}
+	path := uriToPath(p.TextDocument.URI)
+	text, ok := s.read(path)
+	if !ok || filepath.Base(path) != "plan.md" {
+		return out
+	}
+	ls := lines(text)
+	n := p.Range.Start.Line
+
+	mark := func(title string, line, col int, m string) codeAction {
+		a := codeAction{Title: title, Kind: actionRewrite}
+		a.Edit.Changes = map[string][]textEdit{p.TextDocument.URI: {{
+			Range:   lspRange{Start: position{Line: line, Character: col}, End: position{Line: line, Character: col + 1}},
+			NewText: m,
+		}}}
+		return a
+	}
+	if n < len(ls) {
+		if m := conductor.TaskRe.FindStringSubmatch(ls[n]); m != nil {
+			for _, st := range []struct{ mark, title string }{
+				{"x", "Mark task completed"}, {"~", "Mark task in progress"}, {" ", "Mark task pending"},
+			} {
+				if st.mark != m[1] {
+					out = append(out, mark(st.title, n, 3, st.mark))
+				}
+			}
+		} else if m := conductor.SubtaskRe.FindStringSubmatch(ls[n]); m != nil {
+			if m[1] == "x" {
+				out = append(out, mark("Mark sub-task pending", n, 7, " "))
+			} else {
+				out = append(out, mark("Mark sub-task completed", n, 7, "x"))
+			}
+		}
+	}
+
+	next := 1
+	for _, ph := range conductor.ParsePlan(text) {
+		next = max(next, ph.Number+1)
+	}
+	sep := "\n\n"
+	switch {
+	case text == "" || strings.HasSuffix(text, "\n\n"):
+		sep = ""
+	case strings.HasSuffix(text, "\n"):
+		sep = "\n"
+	}
+	last := len(ls) - 1
+	end := position{Line: last, Character: utf16Len(ls[last])}
+	a := codeAction{Title: fmt.Sprintf("Insert phase template (Phase %d)", next), Kind: actionRefactor}
+	a.Edit.Changes = map[string][]textEdit{p.TextDocument.URI: {{
+		Range:   lspRange{Start: end, End: end},
+		NewText: fmt.Sprintf("%s## Phase %d: New phase\n\n- [ ] Task: New task\n    - [ ] New sub-task\n", sep, next),
+	}}}
+	return append(out, a)
+}
+
+// documentSymbols outlines a plan.md as phases containing tasks containing
+// sub-tasks.
+func (s *Server) documentSymbols(uri string) []documentSymbol {
+	out := []documentSymbol{}
+	path := uriToPath(uri)
+	text, ok := s.read(path)
+	if !ok || filepath.Base(path) != "plan.md" {
+		return out
+	}
+	ls := lines(text)
+	phases := conductor.ParsePlan(text)
+	for i, ph := range phases {
+		// A phase runs up to the line before the next one.
+		endLine := len(ls) - 1
+		if i+1 < len(phases) {
+			endLine = phases[i+1].Line - 2
+		}
+		for endLine > ph.Line-1 && strings.TrimSpace(ls[endLine]) == "" {
+			endLine--
+		}
+		heading := lineRange(ls, ph.Line-1)
+		sym := documentSymbol{
+			Name:           fmt.Sprintf("Phase %d: %s", ph.Number, ph.Name),
+			Kind:           symbolModule,
+			Range:          lspRange{Start: heading.Start, End: lineRange(ls, endLine).End},
+			SelectionRange: heading,
+		}
+		if ph.Checkpoint != "" {
+			sym.Detail = "checkpoint " + ph.Checkpoint
+		}
+		for _, t := range ph.Tasks {
+			taskLine := lineRange(ls, t.Line-1)
+			task := documentSymbol{
+				Name:           t.Name,
+				Detail:         strings.TrimSpace(conductor.TaskStatus(t) + " " + t.Commit),
+				Kind:           symbolFunction,
+				Range:          taskLine,
+				SelectionRange: taskLine,
+			}
+			for _, st := range t.SubTasks {
+				r := lineRange(ls, st.Line-1)
+				detail := conductor.TaskPending
+				if st.Completed {
+					detail = conductor.TaskCompleted
+				}
+				task.Children = append(task.Children, documentSymbol{Name: st.Name, Detail: detail, Kind: symbolField, Range: r, SelectionRange: r})
+				task.Range.End = r.End
+			}
+			sym.Children = append(sym.Children, task)
+		}
+		out = append(out, sym)
+	}
+	return out
+}
+
+// sortedPaths returns the open document paths in a stable order.
+func (s *Server) sortedPaths() []string {
+	paths := make([]string, 0, len(s.docs))
+	for p := range s.docs {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	return paths
+}
@@ -0,0 +1,299 @@
+// Package lsp is a Language Server Protocol server for Conductor plan.md and
+// metadata.json files. It parses with pkg/conductor, so the editor and the
+// TUI read the same plan the same way.
+package lsp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// document is an open editor buffer.
+type document struct {
+	uri  string
+	text string
+}
+
+// Server answers LSP requests. Open documents are keyed by file path.
+type Server struct {
+	Version string
+
+	docs     map[string]document
+	out      *bufio.Writer
+	shutdown bool
+}
+
+// New returns a server reporting version to clients.
+func New(version string) *Server {
+	return &Server{Version: version, docs: map[string]document{}}
+}
+
+// errExit stops Serve after the exit notification.
+var errExit = errors.New("exit")
+
+// Serve reads Content-Length framed JSON-RPC messages from r and writes
+// responses and notifications to w until the client sends exit, r is
+// exhausted or ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	in := bufio.NewReader(r)
+	s.out = bufio.NewWriter(w)
+	for ctx.Err() == nil {
+		body, err := readMessage(in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.handle(body); err != nil {
+			if errors.Is(err, errExit) {
+				return nil
+			}
+			return err
+		}
+		if err := s.out.Flush(); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// readMessage reads one message body after its headers.
+func readMessage(r *bufio.Reader) ([]byte, error) {
+	headers, err := textproto.NewReader(r).ReadMIMEHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) && len(headers) == 0 {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("reading headers: %w", err)
+	}
+	n, err := strconv.Atoi(headers.Get("Content-Length"))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid Content-Length %q", headers.Get("Content-Length"))
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+	return body, nil
+}
+
+func (s *Server) write(m message) error {
+	m.JSONRPC = "2.0"
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.out, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = s.out.Write(body)
+	return err
+}
+
+func (s *Server) notify(method string, params any) error {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return s.write(message{Method: method, Params: raw})
+}
+
+func (s *Server) handle(body []byte) error {
+	var req message
+	if err := json.Unmarshal(body, &req); err != nil {
+		return s.write(message{ID: json.RawMessage("null"),
+			Error: &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}})
+	}
+	if len(req.ID) == 0 {
+		return s.handleNotification(req.Method, req.Params)
+	}
+	if req.Method == "" {
+		return s.write(message{ID: req.ID, Error: &rpcError{Code: codeInvalidRequest, Message: "invalid request"}})
+	}
+	result, err := s.dispatch(req.Method, req.Params)
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.write(message{ID: req.ID, Error: rerr})
+	}
+	if result == nil {
+		// A null result must still be sent; omitempty would drop it.
+		return s.write(message{ID: req.ID, Result: json.RawMessage("null")})
+	}
+	return s.write(message{ID: req.ID, Result: result})
+}
+
+func (s *Server) dispatch(method string, params json.RawMessage) (any, error) {
+	if s.shutdown {
+		return nil, &rpcError{Code: codeInvalidRequest, Message: "server is shut down"}
+	}
+	switch method {
+	case "initialize":
+		return s.initialize(), nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/hover":
+		var p textDocumentPositionParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.hover(p), nil
+	case "textDocument/codeAction":
+		var p codeActionParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.codeActions(p), nil
+	case "textDocument/documentSymbol":
+		var p struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+		}
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.documentSymbols(p.TextDocument.URI), nil
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", method)}
+}
+
+func (s *Server) handleNotification(method string, params json.RawMessage) error {
+	switch method {
+	case "exit":
+		return errExit
+	case "textDocument/didOpen":
+		var p struct {
+			TextDocument struct {
+				URI  string `json:"uri"`
+				Text string `json:"text"`
+			} `json:"textDocument"`
+		}
+		if decodeParams(params, &p) != nil {
+			return nil
+		}
+		return s.setDocument(p.TextDocument.URI, p.TextDocument.Text)
+	case "textDocument/didChange":
+		var p struct {
+			TextDocument   textDocumentIdentifier `json:"textDocument"`
+			ContentChanges []struct {
+				Text string `json:"text"`
+			} `json:"contentChanges"`
+		}
+		if decodeParams(params, &p) != nil || len(p.ContentChanges) == 0 {
+			return nil
+		}
+		// Full sync: the last change holds the whole document.
+		return s.setDocument(p.TextDocument.URI, p.ContentChanges[len(p.ContentChanges)-1].Text)
+	case "textDocument/didClose":
+		var p struct {
+			TextDocument textDocumentIdentifier `json:"textDocument"`
+		}
+		if decodeParams(params, &p) != nil {
+			return nil
+		}
+		path := uriToPath(p.TextDocument.URI)
+		delete(s.docs, path)
+		if err := s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: p.TextDocument.URI, Diagnostics: []diagnostic{}}); err != nil {
+			return err
+		}
+		return s.republish(filepath.Dir(path))
+	}
+	// initialized, didSave, $/cancelRequest and the rest need no action.
+	return nil
+}
+
+func (s *Server) initialize() any {
+	return map[string]any{
+		"capabilities": map[string]any{
+			"textDocumentSync":       map[string]any{"openClose": true, "change": 1},
+			"hoverProvider":          true,
+			"codeActionProvider":     map[string]any{"codeActionKinds": []string{actionRewrite, actionRefactor}},
+			"documentSymbolProvider": true,
+		},
+		"serverInfo": map[string]any{"name": "conductor-tui", "version": s.Version},
+	}
+}
+
+// setDocument stores a buffer and republishes diagnostics for every open
+// document in its directory, since plan.md checks depend on metadata.json
+// and the other way round.
+func (s *Server) setDocument(uri, text string) error {
+	path := uriToPath(uri)
+	s.docs[path] = document{uri: uri, text: text}
+	return s.republish(filepath.Dir(path))
+}
+
+func (s *Server) republish(dir string) error {
+	for _, path := range s.sortedPaths() {
+		if filepath.Dir(path) != dir {
+			continue
+		}
+		doc := s.docs[path]
+		diags := s.diagnostics(path, doc.text)
+		if err := s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{URI: doc.uri, Diagnostics: diags}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
+
+// uriToPath converts a file:// URI to a file path. Other URIs are
+// returned unchanged so they still work as document keys.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	p := u.Path
+	if runtime.GOOS == "windows" {
+		p = strings.TrimPrefix(p, "/")
+	}
+	return filepath.Clean(filepath.FromSlash(p))
+}
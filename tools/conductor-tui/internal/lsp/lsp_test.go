@@ -0,0 +1,293 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const plan = `# Plan
+
+## Phase 1: Setup [checkpoint: abc1234]
+
+- [x] Task: Initialize project ` + "`abc1234`" + `
+    - [x] Create layout
+- [~] Task: Add dependencies
+- [X] Task: Bad mark
+
+## Phase 3: Build
+`
+
+// newTrack writes a track under a temp project and returns the plan path.
+func newTrack(t *testing.T, status string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "conductor", "tracks", "alpha_20260101")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := fmt.Sprintf(`{"track_id": "alpha_20260101", "type": "feature", "status": %q, "description": "Alpha"}`, status)
+	if err := os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "plan.md")
+	if err := os.WriteFile(path, []byte(plan), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func fileURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// session runs the server over the given messages and returns everything
+// it wrote, decoded.
+func session(t *testing.T, msgs ...map[string]any) []message {
+	t.Helper()
+	var in bytes.Buffer
+	for _, m := range msgs {
+		m["jsonrpc"] = "2.0"
+		body, _ := json.Marshal(m)
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	}
+	var out bytes.Buffer
+	if err := New("test").Serve(context.Background(), &in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var got []message
+	r := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		var m message
+		if err := json.Unmarshal(body, &m); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, m)
+	}
+	return got
+}
+
+func open(uri, text string) map[string]any {
+	return map[string]any{"method": "textDocument/didOpen", "params": map[string]any{
+		"textDocument": map[string]any{"uri": uri, "languageId": "markdown", "version": 1, "text": text},
+	}}
+}
+
+func request(id int, method string, params any) map[string]any {
+	return map[string]any{"id": id, "method": method, "params": params}
+}
+
+// response returns the result of request id, decoded into v.
+func response(t *testing.T, msgs []message, id int, v any) {
+	t.Helper()
+	for _, m := range msgs {
+		if string(m.ID) == fmt.Sprint(id) {
+			if m.Error != nil {
+				t.Fatalf("request %d failed: %v", id, m.Error)
+			}
+			raw, _ := json.Marshal(m.Result)
+			if err := json.Unmarshal(raw, v); err != nil {
+				t.Fatal(err)
+			}
+			return
+		}
+	}
+	t.Fatalf("no response to request %d", id)
+}
+
+// published returns the last diagnostics published for uri.
+func published(msgs []message, uri string) []diagnostic {
+	var diags []diagnostic
+	for _, m := range msgs {
+		if m.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+		var p publishDiagnosticsParams
+		json.Unmarshal(m.Params, &p)
+		if p.URI == uri {
+			diags = p.Diagnostics
+		}
+	}
+	return diags
+}
+
+func TestServe_Lifecycle(t *testing.T) {
+	msgs := session(t,
+		request(1, "initialize", map[string]any{"capabilities": map[string]any{}}),
+		map[string]any{"method": "initialized", "params": map[string]any{}},
+		request(2, "shutdown", nil),
+		request(3, "textDocument/hover", nil),
+		map[string]any{"method": "exit"},
+		request(4, "never/answered", nil),
+	)
+	var init struct {
+		Capabilities map[string]any `json:"capabilities"`
+		ServerInfo   struct{ Name string }
+	}
+	response(t, msgs, 1, &init)
+	if init.ServerInfo.Name != "conductor-tui" || init.Capabilities["hoverProvider"] != true || init.Capabilities["documentSymbolProvider"] != true {
+		t.Errorf("initialize = %+v", init)
+	}
+	if len(msgs) != 3 || string(msgs[1].ID) != "2" || msgs[2].Error == nil || msgs[2].Error.Code != codeInvalidRequest {
+		t.Errorf("messages = %+v", msgs)
+	}
+}
+
+func TestDiagnostics_Plan(t *testing.T) {
+	path := newTrack(t, "completed")
+	uri := fileURI(path)
+	msgs := session(t, open(uri, plan))
+
+	byLine := map[int][]string{}
+	for _, d := range published(msgs, uri) {
+		byLine[d.Range.Start.Line] = append(byLine[d.Range.Start.Line], d.Code+":"+d.Message)
+	}
+	for line, want := range map[int]string{
+		7: "task is not recognised",                 // - [X]
+		9: "phase 3 follows phase 1",                // numbering
+		6: "completed-unchecked:track is completed", // check rule, [~] task
+		2: "",                                       // well-formed phase: no diagnostics
+	} {
+		got := strings.Join(byLine[line], "\n")
+		if want == "" && got != "" || !strings.Contains(got, want) {
+			t.Errorf("line %d diagnostics = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestDiagnostics_MetadataFollowsEdits(t *testing.T) {
+	path := newTrack(t, "in_progress")
+	metaURI := fileURI(filepath.Join(filepath.Dir(path), "metadata.json"))
+	msgs := session(t, open(metaURI, "{\n  \"track_id\": \"beta_20260101\",\n  \"status\": 3\n}\n"))
+
+	diags := published(msgs, metaURI)
+	if len(diags) != 1 || diags[0].Severity != severityError || diags[0].Range.Start.Line != 2 {
+		t.Fatalf("diagnostics = %+v", diags)
+	}
+
+	msgs = session(t, open(metaURI, `{"track_id": "beta_20260101", "type": "feature", "status": "in_progress", "description": "x"}`))
+	diags = published(msgs, metaURI)
+	if len(diags) != 1 || !strings.Contains(diags[0].Message, "does not match the directory name") {
+		t.Errorf("diagnostics = %+v", diags)
+	}
+}
+
+func TestHover_CommitSHA(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	path := newTrack(t, "in_progress")
+	root := filepath.Dir(filepath.Dir(filepath.Dir(filepath.Dir(path))))
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "Initialize project", "-m", "Details here.")
+	sha := git("rev-parse", "HEAD")
+
+	text := strings.Replace(plan, "`abc1234`", "`"+sha[:7]+"`", 1)
+	uri := fileURI(path)
+	col := strings.Index(strings.Split(text, "\n")[4], sha[:7]) + 2
+	msgs := session(t, open(uri, text),
+		request(1, "textDocument/hover", map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 4, "character": col}}),
+		request(2, "textDocument/hover", map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 2, "character": 35}}),
+		request(3, "textDocument/hover", map[string]any{"textDocument": map[string]any{"uri": uri}, "position": map[string]any{"line": 4, "character": 1}}),
+	)
+
+	var h struct{ Contents struct{ Kind, Value string } }
+	response(t, msgs, 1, &h)
+	if !strings.Contains(h.Contents.Value, "Initialize project") || !strings.Contains(h.Contents.Value, "Test <test@example.com>") ||
+		!strings.Contains(h.Contents.Value, "Details here.") {
+		t.Errorf("hover = %+v", h)
+	}
+	response(t, msgs, 2, &h)
+	if !strings.Contains(h.Contents.Value, "`abc1234` was not found") {
+		t.Errorf("checkpoint hover = %+v", h)
+	}
+	if string(msgs[len(msgs)-1].ID) != "3" || msgs[len(msgs)-1].Result != nil {
+		t.Errorf("hover off the SHA = %+v", msgs[len(msgs)-1])
+	}
+}
+
+func TestCodeActions(t *testing.T) {
+	path := newTrack(t, "in_progress")
+	uri := fileURI(path)
+	at := func(id, line int) map[string]any {
+		return request(id, "textDocument/codeAction", map[string]any{
+			"textDocument": map[string]any{"uri": uri},
+			"range":        map[string]any{"start": map[string]any{"line": line, "character": 0}, "end": map[string]any{"line": line, "character": 0}},
+			"context":      map[string]any{"diagnostics": []any{}},
+		})
+	}
+	msgs := session(t, open(uri, plan), at(1, 6), at(2, 5), at(3, 0))
+
+	var actions []codeAction
+	response(t, msgs, 1, &actions)
+	var titles []string
+	for _, a := range actions {
+		titles = append(titles, a.Title)
+	}
+	if strings.Join(titles, "|") != "Mark task completed|Mark task pending|Insert phase template (Phase 4)" {
+		t.Errorf("task actions = %v", titles)
+	}
+	edit := actions[0].Edit.Changes[uri][0]
+	if edit.NewText != "x" || edit.Range.Start != (position{Line: 6, Character: 3}) || edit.Range.End.Character != 4 {
+		t.Errorf("toggle edit = %+v", edit)
+	}
+	tmpl := actions[2].Edit.Changes[uri][0]
+	if !strings.HasPrefix(tmpl.NewText, "\n## Phase 4: New phase\n") || tmpl.Range.Start.Line != 10 {
+		t.Errorf("template edit = %+v", tmpl)
+	}
+
+	response(t, msgs, 2, &actions)
+	if actions[0].Title != "Mark sub-task pending" || actions[0].Edit.Changes[uri][0].Range.Start.Character != 7 {
+		t.Errorf("sub-task actions = %+v", actions)
+	}
+	response(t, msgs, 3, &actions)
+	if len(actions) != 1 {
+		t.Errorf("actions on a heading = %+v", actions)
+	}
+}
+
+func TestDocumentSymbols(t *testing.T) {
+	path := newTrack(t, "in_progress")
+	uri := fileURI(path)
+	msgs := session(t, open(uri, plan),
+		request(1, "textDocument/documentSymbol", map[string]any{"textDocument": map[string]any{"uri": uri}}))
+
+	var syms []documentSymbol
+	response(t, msgs, 1, &syms)
+	if len(syms) != 2 || syms[0].Name != "Phase 1: Setup" || syms[0].Detail != "checkpoint abc1234" || syms[1].Name != "Phase 3: Build" {
+		t.Fatalf("symbols = %+v", syms)
+	}
+	if syms[0].Range.Start.Line != 2 || syms[0].Range.End.Line != 7 {
+		t.Errorf("phase range = %+v", syms[0].Range)
+	}
+	tasks := syms[0].Children
+	if len(tasks) != 2 || tasks[0].Detail != "completed abc1234" || tasks[1].Detail != "in_progress" {
+		t.Fatalf("tasks = %+v", tasks)
+	}
+	if len(tasks[0].Children) != 1 || tasks[0].Range.End.Line != 5 {
+		t.Errorf("task with sub-task = %+v", tasks[0])
+	}
+}
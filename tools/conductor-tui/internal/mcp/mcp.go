@@ -0,0 +1,169 @@
+// Package mcp serves conductor tracks to AI agents over the Model Context
+// Protocol: newline-delimited JSON-RPC 2.0 on stdin and stdout.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// ProtocolVersion is the newest MCP revision the server speaks.
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions lists the revisions accepted from clients, newest first.
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// JSON-RPC and MCP error codes.
+const (
+	codeParseError       = -32700
+	codeInvalidRequest   = -32600
+	codeMethodNotFound   = -32601
+	codeInvalidParams    = -32602
+	codeResourceNotFound = -32002
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// Server answers MCP requests for the project at BasePath.
+type Server struct {
+	BasePath string
+	Version  string
+}
+
+// New returns a server for the project at basePath, reporting version to
+// clients.
+func New(basePath, version string) *Server {
+	return &Server{BasePath: basePath, Version: version}
+}
+
+// Serve reads one JSON-RPC message per line from r and writes responses to
+// w until r is exhausted or ctx is cancelled. Requests are handled in order.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	in := bufio.NewReader(r)
+	enc := json.NewEncoder(w)
+	for ctx.Err() == nil {
+		line, err := in.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if resp := s.handleMessage(line); resp != nil {
+				if err := enc.Encode(resp); err != nil {
+					return err
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// handleMessage returns the response to one message, or nil for a
+// notification.
+func (s *Server) handleMessage(line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &rpcError{Code: codeParseError, Message: "parse error: " + err.Error()}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &response{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: codeInvalidRequest, Message: "invalid request"}
+		return resp
+	}
+	result, err := s.dispatch(req.Method, req.Params)
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		resp.Error = rerr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) dispatch(method string, params json.RawMessage) (any, error) {
+	switch method {
+	case "initialize":
+		return s.initialize(params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.toolList()}, nil
+	case "tools/call":
+		return s.callTool(params)
+	case "resources/list":
+		return map[string]any{"resources": s.listResources()}, nil
+	case "resources/templates/list":
+		return map[string]any{"resourceTemplates": resourceTemplates}, nil
+	case "resources/read":
+		return s.readResource(params)
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", method)}
+}
+
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	version := ProtocolVersion
+	if slices.Contains(supportedVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	return map[string]any{
+		"protocolVersion": version,
+		"capabilities": map[string]any{
+			"tools":     map[string]any{"listChanged": false},
+			"resources": map[string]any{"listChanged": false, "subscribe": false},
+		},
+		"serverInfo": map[string]any{"name": "conductor-tui", "version": s.Version},
+		"instructions": "Conductor tracks live under conductor/tracks. Use get_next_task to find work, " +
+			"set_task_status to mark progress in plan.md, and read a track's spec.md and plan.md resources for context.",
+	}, nil
+}
+
+// decodeParams unmarshals params into v, treating absent params as empty.
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return fmt.Errorf("invalid params: %w", err)
+	}
+	return nil
+}
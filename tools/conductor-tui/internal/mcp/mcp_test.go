@@ -0,0 +1,274 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// newProject copies the discovery fixture so tests can write to it.
+func newProject(t *testing.T) string {
+	t.Helper()
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	spec := filepath.Join(base, "conductor", "tracks", "feature-alpha_20260101", "spec.md")
+	if err := os.WriteFile(spec, []byte("# Spec\n\nAlpha.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return base
+}
+
+type rpcResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// roundTrip sends the requests, one per line, and returns the responses.
+func roundTrip(t *testing.T, base string, lines ...string) []rpcResponse {
+	t.Helper()
+	var out bytes.Buffer
+	in := strings.NewReader(strings.Join(lines, "\n") + "\n")
+	if err := New(base, "test").Serve(context.Background(), in, &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var resps []rpcResponse
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var r rpcResponse
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("decode response: %v\n%s", err, out.String())
+		}
+		resps = append(resps, r)
+	}
+	return resps
+}
+
+func call(id int, method string, params any) string {
+	raw, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": id, "method": method, "params": params})
+	return string(raw)
+}
+
+type toolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent"`
+	IsError           bool            `json:"isError"`
+}
+
+// callTool runs one tool and returns its result.
+func callTool(t *testing.T, base, name string, args any) toolResult {
+	t.Helper()
+	resps := roundTrip(t, base, call(1, "tools/call", map[string]any{"name": name, "arguments": args}))
+	if len(resps) != 1 || resps[0].Error != nil {
+		t.Fatalf("%s: responses = %+v", name, resps)
+	}
+	var r toolResult
+	if err := json.Unmarshal(resps[0].Result, &r); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestServe_Initialize(t *testing.T) {
+	resps := roundTrip(t, newProject(t),
+		call(1, "initialize", map[string]any{"protocolVersion": "2025-03-26", "capabilities": map[string]any{}}),
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		call(2, "ping", nil),
+	)
+	if len(resps) != 2 {
+		t.Fatalf("want 2 responses (notifications get none), got %d", len(resps))
+	}
+	var init struct {
+		ProtocolVersion string         `json:"protocolVersion"`
+		Capabilities    map[string]any `json:"capabilities"`
+		ServerInfo      struct{ Name, Version string }
+	}
+	json.Unmarshal(resps[0].Result, &init)
+	if init.ProtocolVersion != "2025-03-26" || init.ServerInfo.Name != "conductor-tui" || init.ServerInfo.Version != "test" {
+		t.Errorf("initialize = %s", resps[0].Result)
+	}
+	if init.Capabilities["tools"] == nil || init.Capabilities["resources"] == nil {
+		t.Errorf("capabilities = %v", init.Capabilities)
+	}
+	if string(resps[1].ID) != "2" || string(resps[1].Result) != "{}" {
+		t.Errorf("ping = %+v", resps[1])
+	}
+}
+
+func TestServe_Errors(t *testing.T) {
+	resps := roundTrip(t, newProject(t),
+		`{not json`,
+		call(1, "no/such", nil),
+		call(2, "tools/call", map[string]any{"name": "nope"}),
+	)
+	want := []int{codeParseError, codeMethodNotFound, codeInvalidParams}
+	for i, r := range resps {
+		if r.Error == nil || r.Error.Code != want[i] {
+			t.Errorf("response %d = %+v, want code %d", i, r, want[i])
+		}
+	}
+}
+
+func TestToolsList(t *testing.T) {
+	resps := roundTrip(t, newProject(t), call(1, "tools/list", nil))
+	var got struct {
+		Tools []struct {
+			Name        string         `json:"name"`
+			InputSchema map[string]any `json:"inputSchema"`
+		} `json:"tools"`
+	}
+	json.Unmarshal(resps[0].Result, &got)
+	var names []string
+	for _, tl := range got.Tools {
+		names = append(names, tl.Name)
+		if tl.InputSchema["type"] != "object" {
+			t.Errorf("%s: schema = %v", tl.Name, tl.InputSchema)
+		}
+	}
+	if fmt.Sprint(names) != "[list_tracks get_track get_next_task set_task_status set_track_status]" {
+		t.Errorf("tools = %v", names)
+	}
+}
+
+func TestListTracks(t *testing.T) {
+	r := callTool(t, newProject(t), "list_tracks", map[string]any{"status": "in_progress"})
+	var got struct{ Tracks []trackSummary }
+	if err := json.Unmarshal(r.StructuredContent, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Tracks) != 1 || got.Tracks[0].TrackID != "feature-alpha_20260101" || got.Tracks[0].Progress.Total != 2 {
+		t.Errorf("tracks = %+v", got.Tracks)
+	}
+	if !strings.Contains(r.Content[0].Text, "feature-alpha_20260101") {
+		t.Error("text content should carry the same JSON")
+	}
+}
+
+func TestGetTrack(t *testing.T) {
+	base := newProject(t)
+	r := callTool(t, base, "get_track", map[string]any{"track_id": "feature-alpha_20260101"})
+	var got trackInfo
+	json.Unmarshal(r.StructuredContent, &got)
+	if r.IsError || len(got.Phases) != 1 || got.Phases[0].Tasks[0].Commit != "abc1234" || got.Percent != 50 {
+		t.Errorf("get_track = %+v", got)
+	}
+
+	r = callTool(t, base, "get_track", map[string]any{"track_id": "missing"})
+	if !r.IsError || !strings.Contains(r.Content[0].Text, "not found") {
+		t.Errorf("missing track = %+v", r)
+	}
+}
+
+func TestGetNextTask(t *testing.T) {
+	r := callTool(t, newProject(t), "get_next_task", nil)
+	var got struct{ Task *taskRef }
+	json.Unmarshal(r.StructuredContent, &got)
+	if got.Task == nil || got.Task.TrackID != "feature-alpha_20260101" || got.Task.Task != "Add dependencies" || got.Task.Status != conductor.TaskPending {
+		t.Errorf("next = %+v", got.Task)
+	}
+}
+
+func TestSetTaskStatus(t *testing.T) {
+	base := newProject(t)
+	r := callTool(t, base, "set_task_status", map[string]any{
+		"track_id": "feature-alpha_20260101", "phase": 1, "task": "Add dependencies",
+		"status": "completed", "commit": "def5678",
+	})
+	if r.IsError {
+		t.Fatalf("set_task_status: %s", r.Content[0].Text)
+	}
+	plan, _ := os.ReadFile(filepath.Join(base, "conductor", "tracks", "feature-alpha_20260101", "plan.md"))
+	if !strings.Contains(string(plan), "- [x] Task: Add dependencies `def5678`\n") ||
+		!strings.Contains(string(plan), "- [x] Task: Initialize project `abc1234`\n") {
+		t.Errorf("plan.md =\n%s", plan)
+	}
+
+	r = callTool(t, base, "get_next_task", map[string]any{"track_id": "feature-alpha_20260101"})
+	if string(r.StructuredContent) != `{"task":null}` {
+		t.Errorf("next after completing everything = %s", r.StructuredContent)
+	}
+
+	r = callTool(t, base, "set_task_status", map[string]any{
+		"track_id": "feature-alpha_20260101", "phase": 1, "task": "Add dependencies", "status": "done",
+	})
+	if !r.IsError {
+		t.Error("unknown status should be a tool error")
+	}
+}
+
+func TestSetTrackStatus(t *testing.T) {
+	base := newProject(t)
+	r := callTool(t, base, "set_track_status", map[string]any{"track_id": "bugfix-beta_20260102", "status": "in_progress"})
+	if r.IsError {
+		t.Fatalf("set_track_status: %s", r.Content[0].Text)
+	}
+	raw, _ := os.ReadFile(filepath.Join(base, "conductor", "tracks", "bugfix-beta_20260102", "metadata.json"))
+	track, err := conductor.LoadMetadata(raw)
+	if err != nil || track.Status != "in_progress" || track.CreatedAt.IsZero() {
+		t.Errorf("metadata = %+v (%v)", track, err)
+	}
+
+	r = callTool(t, base, "set_track_status", map[string]any{"track_id": "bugfix-beta_20260102", "status": "bogus"})
+	if !r.IsError {
+		t.Error("unknown status should be a tool error")
+	}
+
+	// The project's configured statuses replace the defaults.
+	if err := os.WriteFile(filepath.Join(base, "conductor", "tui.json"), []byte(`{"statuses": ["todo", "blocked", "done"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if r = callTool(t, base, "set_track_status", map[string]any{"track_id": "bugfix-beta_20260102", "status": "blocked"}); r.IsError {
+		t.Errorf("configured status rejected: %s", r.Content[0].Text)
+	}
+	var list struct{ Tools []tool }
+	json.Unmarshal(roundTrip(t, base, call(1, "tools/list", nil))[0].Result, &list)
+	if status := list.Tools[4].InputSchema["properties"].(map[string]any)["status"]; !strings.Contains(fmt.Sprint(status), "[todo blocked done]") {
+		t.Errorf("set_track_status status schema = %v", status)
+	}
+}
+
+func TestResources(t *testing.T) {
+	base := newProject(t)
+	resps := roundTrip(t, base,
+		call(1, "resources/list", nil),
+		call(2, "resources/read", map[string]any{"uri": "conductor://tracks/feature-alpha_20260101/spec.md"}),
+		call(3, "resources/read", map[string]any{"uri": "conductor://tracks/bugfix-beta_20260102/plan.md"}),
+		call(4, "resources/templates/list", nil),
+	)
+
+	var list struct{ Resources []resource }
+	json.Unmarshal(resps[0].Result, &list)
+	var uris []string
+	for _, r := range list.Resources {
+		uris = append(uris, r.URI)
+	}
+	if fmt.Sprint(uris) != "[conductor://tracks/feature-alpha_20260101/spec.md conductor://tracks/feature-alpha_20260101/plan.md]" {
+		t.Errorf("resources = %v", uris)
+	}
+
+	var read struct {
+		Contents []struct{ URI, MimeType, Text string }
+	}
+	json.Unmarshal(resps[1].Result, &read)
+	if len(read.Contents) != 1 || read.Contents[0].Text != "# Spec\n\nAlpha.\n" || read.Contents[0].MimeType != "text/markdown" {
+		t.Errorf("read = %s", resps[1].Result)
+	}
+	if resps[2].Error == nil || resps[2].Error.Code != codeResourceNotFound {
+		t.Errorf("missing plan = %+v", resps[2])
+	}
+	if !strings.Contains(string(resps[3].Result), "{track_id}/plan.md") {
+		t.Errorf("templates = %s", resps[3].Result)
+	}
+}
@@ -0,0 +1,409 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// tool is an MCP tool definition with its handler.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	call        func(s *Server, args json.RawMessage) (any, error)
+}
+
+func object(required []string, props map[string]any) map[string]any {
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func str(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func enum(description string, values []string) map[string]any {
+	return map[string]any{"type": "string", "description": description, "enum": values}
+}
+
+// toolList returns the tools with the project's track statuses in the
+// schema of set_track_status.
+func (s *Server) toolList() []tool {
+	list := slices.Clone(tools)
+	for i, t := range list {
+		if t.Name == "set_track_status" {
+			props := maps.Clone(t.InputSchema["properties"].(map[string]any))
+			props["status"] = enum("new track status", s.statuses())
+			list[i].InputSchema = object([]string{"track_id", "status"}, props)
+		}
+	}
+	return list
+}
+
+// statuses returns the track statuses of the project: those configured in
+// conductor/tui.json, or the defaults.
+func (s *Server) statuses() []string {
+	cfg, _ := config.Load(s.BasePath)
+	return cfg.Statuses.Values()
+}
+
+var tools = []tool{
+	{
+		Name:        "list_tracks",
+		Description: "List conductor tracks with their status, type and task progress, newest first.",
+		InputSchema: object(nil, map[string]any{
+			"status":   str("only tracks with this status"),
+			"type":     str("only tracks of this type"),
+			"archived": map[string]any{"type": "boolean", "description": "include archived tracks"},
+		}),
+		call: (*Server).listTracks,
+	},
+	{
+		Name:        "get_track",
+		Description: "Get a track's metadata and its full plan: phases, tasks, sub-tasks, commits and plan.md line numbers.",
+		InputSchema: object([]string{"track_id"}, map[string]any{"track_id": str("track ID, e.g. auth_20260301")}),
+		call:        (*Server).getTrack,
+	},
+	{
+		Name: "get_next_task",
+		Description: "Get the task to work on next: the first task marked in progress, otherwise the first pending task. " +
+			"Without track_id, in_progress tracks are searched before others.",
+		InputSchema: object(nil, map[string]any{"track_id": str("restrict to this track")}),
+		call:        (*Server).getNextTask,
+	},
+	{
+		Name:        "set_task_status",
+		Description: "Set a task's checkbox in plan.md ([ ] pending, [~] in_progress, [x] completed), optionally recording its commit SHA.",
+		InputSchema: object([]string{"track_id", "phase", "task", "status"}, map[string]any{
+			"track_id": str("track ID"),
+			"phase":    map[string]any{"type": "integer", "description": "phase number"},
+			"task":     str("task name as shown by get_track"),
+			"status":   enum("new task status", conductor.TaskStatusValues),
+			"commit":   str("commit SHA to record after the task name"),
+		}),
+		call: (*Server).setTaskStatus,
+	},
+	{
+		Name:        "set_track_status",
+		Description: "Set a track's status in metadata.json, following the project's allowed transitions and recording started_at, completed_at or cancelled_at.",
+		InputSchema: object([]string{"track_id", "status"}, map[string]any{
+			"track_id": str("track ID"),
+			"status":   enum("new track status", conductor.StatusValues),
+		}),
+		call: (*Server).setTrackStatus,
+	},
+}
+
+// callTool runs a tool. Failures inside the tool are reported as a result
+// with isError set, so the agent sees the message.
+func (s *Server) callTool(params json.RawMessage) (any, error) {
+	var p struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(tools, func(t tool) bool { return t.Name == p.Name })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown tool %q", p.Name)
+	}
+	v, err := tools[i].call(s, p.Arguments)
+	if err != nil {
+		return map[string]any{
+			"content": []map[string]any{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		}, nil
+	}
+	text, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"content":           []map[string]any{{"type": "text", "text": string(text)}},
+		"structuredContent": v,
+	}, nil
+}
+
+// trackInfo is a track with its progress, as returned by the tools.
+type trackInfo struct {
+	conductor.Track
+	Progress conductor.Progress `json:"progress"`
+	Percent  int                `json:"percent"`
+}
+
+func info(t conductor.Track) trackInfo {
+	p := conductor.TrackProgress(t)
+	return trackInfo{Track: t, Progress: p, Percent: p.Percent()}
+}
+
+// trackSummary is a trackInfo without the plan.
+type trackSummary struct {
+	TrackID     string             `json:"track_id"`
+	Type        string             `json:"type"`
+	Status      string             `json:"status"`
+	Description string             `json:"description"`
+	Source      string             `json:"source"`
+	Progress    conductor.Progress `json:"progress"`
+	Percent     int                `json:"percent"`
+}
+
+func summarize(t conductor.Track) trackSummary {
+	p := conductor.TrackProgress(t)
+	return trackSummary{
+		TrackID: t.TrackID, Type: t.Type, Status: t.Status, Description: t.Description,
+		Source: t.Source, Progress: p, Percent: p.Percent(),
+	}
+}
+
+// taskRef locates a task for get_next_task and set_task_status.
+type taskRef struct {
+	TrackID   string              `json:"track_id"`
+	Phase     int                 `json:"phase"`
+	PhaseName string              `json:"phase_name"`
+	Task      string              `json:"task"`
+	Status    string              `json:"status"`
+	Commit    string              `json:"commit,omitempty"`
+	Line      int                 `json:"line"`
+	SubTasks  []conductor.SubTask `json:"sub_tasks,omitempty"`
+}
+
+func ref(t conductor.Track, p conductor.Phase, task conductor.Task) *taskRef {
+	return &taskRef{
+		TrackID: t.TrackID, Phase: p.Number, PhaseName: p.Name, Task: task.Name,
+		Status: conductor.TaskStatus(task), Commit: task.Commit, Line: task.Line, SubTasks: task.SubTasks,
+	}
+}
+
+func (s *Server) findTrack(id string) (conductor.Track, error) {
+	if id == "" {
+		return conductor.Track{}, fmt.Errorf("track_id is required")
+	}
+	return conductor.New(s.BasePath).Track(id)
+}
+
+func (s *Server) listTracks(args json.RawMessage) (any, error) {
+	var a struct {
+		Status   string `json:"status"`
+		Type     string `json:"type"`
+		Archived bool   `json:"archived"`
+	}
+	if err := decodeParams(args, &a); err != nil {
+		return nil, err
+	}
+	out := []trackSummary{}
+	f := conductor.Filter{Archived: a.Archived}
+	if a.Status != "" {
+		f.Statuses = []string{a.Status}
+	}
+	if a.Type != "" {
+		f.Types = []string{a.Type}
+	}
+	for _, t := range conductor.New(s.BasePath).Tracks(f) {
+		out = append(out, summarize(t))
+	}
+	return map[string]any{"tracks": out}, nil
+}
+
+func (s *Server) getTrack(args json.RawMessage) (any, error) {
+	var a struct {
+		TrackID string `json:"track_id"`
+	}
+	if err := decodeParams(args, &a); err != nil {
+		return nil, err
+	}
+	t, err := s.findTrack(a.TrackID)
+	if err != nil {
+		return nil, err
+	}
+	return info(t), nil
+}
+
+func (s *Server) getNextTask(args json.RawMessage) (any, error) {
+	var a struct {
+		TrackID string `json:"track_id"`
+	}
+	if err := decodeParams(args, &a); err != nil {
+		return nil, err
+	}
+	var tracks []conductor.Track
+	if a.TrackID != "" {
+		t, err := s.findTrack(a.TrackID)
+		if err != nil {
+			return nil, err
+		}
+		tracks = []conductor.Track{t}
+	} else {
+		for _, t := range conductor.DiscoverTracks(s.BasePath) {
+			if t.Source != "archived" && t.Status != "completed" && t.Status != "cancelled" {
+				tracks = append(tracks, t)
+			}
+		}
+		// Stable, so discovery order (newest first) holds within each group.
+		slices.SortStableFunc(tracks, func(x, y conductor.Track) int {
+			return boolRank(y.Status == "in_progress") - boolRank(x.Status == "in_progress")
+		})
+	}
+
+	for _, pass := range []string{conductor.TaskInProgress, conductor.TaskPending} {
+		for _, t := range tracks {
+			for _, p := range t.Phases {
+				for _, task := range p.Tasks {
+					if conductor.TaskStatus(task) == pass {
+						return map[string]any{"task": ref(t, p, task)}, nil
+					}
+				}
+			}
+		}
+	}
+	return map[string]any{"task": nil}, nil
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (s *Server) setTaskStatus(args json.RawMessage) (any, error) {
+	var a struct {
+		TrackID string `json:"track_id"`
+		Phase   int    `json:"phase"`
+		Task    string `json:"task"`
+		Status  string `json:"status"`
+		Commit  string `json:"commit"`
+	}
+	if err := decodeParams(args, &a); err != nil {
+		return nil, err
+	}
+	t, err := s.findTrack(a.TrackID)
+	if err != nil {
+		return nil, err
+	}
+	pi := slices.IndexFunc(t.Phases, func(p conductor.Phase) bool { return p.Number == a.Phase })
+	if pi < 0 {
+		return nil, fmt.Errorf("track %q has no phase %d", t.TrackID, a.Phase)
+	}
+	name := strings.TrimSpace(a.Task)
+	ti := slices.IndexFunc(t.Phases[pi].Tasks, func(task conductor.Task) bool { return task.Name == name })
+	if ti < 0 {
+		return nil, fmt.Errorf("phase %d of %q has no task %q", a.Phase, t.TrackID, name)
+	}
+
+	if err := conductor.New(s.BasePath).SetTaskStatus(t, t.Phases[pi].Tasks[ti].Line, a.Status, a.Commit); err != nil {
+		return nil, err
+	}
+
+	// Re-read so the result reflects what is on disk.
+	if t, err = s.findTrack(t.TrackID); err != nil {
+		return nil, err
+	}
+	for _, p := range t.Phases {
+		for _, task := range p.Tasks {
+			if p.Number == a.Phase && task.Name == name {
+				return ref(t, p, task), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("task %q disappeared after saving", name)
+}
+
+func (s *Server) setTrackStatus(args json.RawMessage) (any, error) {
+	var a struct {
+		TrackID string `json:"track_id"`
+		Status  string `json:"status"`
+	}
+	if err := decodeParams(args, &a); err != nil {
+		return nil, err
+	}
+	if statuses := s.statuses(); !slices.Contains(statuses, a.Status) {
+		return nil, fmt.Errorf("unknown status %q (want %s)", a.Status, strings.Join(statuses, ", "))
+	}
+	t, err := s.findTrack(a.TrackID)
+	if err != nil {
+		return nil, err
+	}
+	p := conductor.New(s.BasePath)
+	cfg, _ := config.Load(s.BasePath)
+	p.Workflow = cfg.Workflow
+	if _, err := p.SetStatus(t, a.Status); err != nil {
+		return nil, err
+	}
+	if t, err = s.findTrack(t.TrackID); err != nil {
+		return nil, err
+	}
+	return summarize(t), nil
+}
+
+// Resources are a track's spec.md and plan.md.
+const uriPrefix = "conductor://tracks/"
+
+var resourceFiles = []string{"spec.md", "plan.md"}
+
+type resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType"`
+}
+
+var resourceTemplates = []map[string]any{
+	{"uriTemplate": uriPrefix + "{track_id}/spec.md", "name": "spec", "description": "A track's specification", "mimeType": "text/markdown"},
+	{"uriTemplate": uriPrefix + "{track_id}/plan.md", "name": "plan", "description": "A track's implementation plan", "mimeType": "text/markdown"},
+}
+
+func (s *Server) listResources() []resource {
+	out := []resource{}
+	for _, t := range conductor.DiscoverTracks(s.BasePath) {
+		dir := conductor.TrackDir(s.BasePath, t)
+		for _, f := range resourceFiles {
+			if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+				continue
+			}
+			out = append(out, resource{
+				URI:         uriPrefix + t.TrackID + "/" + f,
+				Name:        t.TrackID + "/" + f,
+				Description: t.Description,
+				MimeType:    "text/markdown",
+			})
+		}
+	}
+	return out
+}
+
+func (s *Server) readResource(params json.RawMessage) (any, error) {
+	var p struct {
+		URI string `json:"uri"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	notFound := &rpcError{Code: codeResourceNotFound, Message: fmt.Sprintf("resource %q not found", p.URI)}
+	id, file, ok := strings.Cut(strings.TrimPrefix(p.URI, uriPrefix), "/")
+	if !strings.HasPrefix(p.URI, uriPrefix) || !ok || !slices.Contains(resourceFiles, file) {
+		return nil, notFound
+	}
+	t, err := s.findTrack(id)
+	if err != nil {
+		return nil, notFound
+	}
+	content, err := os.ReadFile(filepath.Join(conductor.TrackDir(s.BasePath, t), file))
+	if err != nil {
+		return nil, notFound
+	}
+	return map[string]any{
+		"contents": []map[string]any{{"uri": p.URI, "mimeType": "text/markdown", "text": string(content)}},
+	}, nil
+}
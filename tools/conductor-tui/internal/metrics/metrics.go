@@ -0,0 +1,153 @@
+// Package metrics renders track progress in the Prometheus text
+// exposition format, for scraping or the node_exporter textfile collector.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// ContentType is the Prometheus text exposition content type.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// metric is one gauge family and its samples.
+type metric struct {
+	name, help string
+	samples    []sample
+}
+
+type sample struct {
+	labels string
+	value  float64
+}
+
+// Write renders per-track gauges and project-wide totals for tracks. The
+// seconds-since-update gauge is measured from now and omitted for tracks
+// without updated_at; the lifecycle timestamp gauges are omitted for
+// tracks without the timestamp.
+func Write(w io.Writer, tracks []conductor.Track, now time.Time) error {
+	var (
+		tasks      = metric{name: "conductor_track_tasks", help: "Tasks in the track's plan."}
+		done       = metric{name: "conductor_track_tasks_done", help: "Completed tasks in the track's plan."}
+		inProgress = metric{name: "conductor_track_tasks_in_progress", help: "In-progress tasks in the track's plan."}
+		phases     = metric{name: "conductor_track_phases", help: "Phases in the track's plan."}
+		phasesDone = metric{name: "conductor_track_phases_completed", help: "Phases whose tasks are all completed."}
+		since      = metric{name: "conductor_track_seconds_since_update", help: "Seconds since the track's updated_at."}
+		created    = metric{name: "conductor_track_created_timestamp_seconds", help: "The track's created_at as a Unix timestamp."}
+		started    = metric{name: "conductor_track_started_timestamp_seconds", help: "The track's started_at as a Unix timestamp."}
+		finished   = metric{name: "conductor_track_completed_timestamp_seconds", help: "The track's completed_at as a Unix timestamp."}
+		cancelled  = metric{name: "conductor_track_cancelled_timestamp_seconds", help: "The track's cancelled_at as a Unix timestamp."}
+
+		trackCount  = metric{name: "conductor_tracks", help: "Tracks by status."}
+		taskCount   = metric{name: "conductor_tasks", help: "Tasks across all tracks."}
+		doneCount   = metric{name: "conductor_tasks_done", help: "Completed tasks across all tracks."}
+		activeCount = metric{name: "conductor_tasks_in_progress", help: "In-progress tasks across all tracks."}
+		phaseCount  = metric{name: "conductor_phases", help: "Phases across all tracks."}
+		byStatus    = map[string]int{}
+		total       conductor.Progress
+		totalPhases int
+	)
+
+	for _, t := range tracks {
+		labels := formatLabels("track_id", t.TrackID, "type", t.Type, "status", t.Status, "source", t.Source)
+		p := conductor.TrackProgress(t)
+		completed := 0
+		for _, ph := range t.Phases {
+			if conductor.PhaseStatus(ph) == "completed" {
+				completed++
+			}
+		}
+
+		tasks.add(labels, float64(p.Total))
+		done.add(labels, float64(p.Done))
+		inProgress.add(labels, float64(p.InProgress))
+		phases.add(labels, float64(len(t.Phases)))
+		phasesDone.add(labels, float64(completed))
+		if !t.UpdatedAt.IsZero() {
+			since.add(labels, now.Sub(t.UpdatedAt).Seconds())
+		}
+		for _, ts := range []struct {
+			m  *metric
+			at time.Time
+		}{{&created, t.CreatedAt}, {&started, t.StartedAt}, {&finished, t.CompletedAt}, {&cancelled, t.CancelledAt}} {
+			if !ts.at.IsZero() {
+				ts.m.add(labels, float64(ts.at.Unix()))
+			}
+		}
+
+		byStatus[t.Status]++
+		total.Total += p.Total
+		total.Done += p.Done
+		total.InProgress += p.InProgress
+		totalPhases += len(t.Phases)
+	}
+
+	statuses := make([]string, 0, len(byStatus))
+	for s := range byStatus {
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	for _, s := range statuses {
+		trackCount.add(formatLabels("status", s), float64(byStatus[s]))
+	}
+	taskCount.add("", float64(total.Total))
+	doneCount.add("", float64(total.Done))
+	activeCount.add("", float64(total.InProgress))
+	phaseCount.add("", float64(totalPhases))
+
+	var b bytes.Buffer
+	for _, m := range []metric{tasks, done, inProgress, phases, phasesDone, since,
+		created, started, finished, cancelled, trackCount, taskCount, doneCount, activeCount, phaseCount} {
+		m.write(&b)
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// Handler serves the metrics for the tracks discovered under basePath.
+func Handler(basePath string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		Write(w, conductor.DiscoverTracks(basePath), time.Now())
+	})
+}
+
+func (m *metric) add(labels string, value float64) {
+	m.samples = append(m.samples, sample{labels, value})
+}
+
+func (m metric) write(b *bytes.Buffer) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", m.name, m.help, m.name)
+	for _, s := range m.samples {
+		fmt.Fprintf(b, "%s%s %s\n", m.name, s.labels, formatValue(s.value))
+	}
+}
+
+// formatLabels renders name/value pairs as {a="1",b="2"}.
+func formatLabels(pairs ...string) string {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, pairs[i], escape(pairs[i+1])))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escape(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func formatValue(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.3f", v)
+}
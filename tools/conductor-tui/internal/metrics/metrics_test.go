@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+func TestWrite(t *testing.T) {
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	tracks := []conductor.Track{
+		{TrackID: "auth", Type: "feature", Status: "in_progress", Source: "active",
+			UpdatedAt: now.Add(-90 * time.Second), StartedAt: time.Unix(1772000000, 0),
+			Phases: []conductor.Phase{
+				{Number: 1, Tasks: []conductor.Task{{Completed: true}, {Completed: true}}},
+				{Number: 2, Tasks: []conductor.Task{{InProgress: true}, {}}},
+			}},
+		{TrackID: `odd"id`, Type: "bug", Status: "new", Source: "active"},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, tracks, now); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	labels := `{track_id="auth",type="feature",status="in_progress",source="active"}`
+	for _, want := range []string{
+		"# TYPE conductor_track_tasks gauge\n",
+		"conductor_track_tasks" + labels + " 4\n",
+		"conductor_track_tasks_done" + labels + " 2\n",
+		"conductor_track_tasks_in_progress" + labels + " 1\n",
+		"conductor_track_phases" + labels + " 2\n",
+		"conductor_track_phases_completed" + labels + " 1\n",
+		"conductor_track_seconds_since_update" + labels + " 90\n",
+		"conductor_track_started_timestamp_seconds" + labels + " 1772000000\n",
+		`conductor_track_tasks{track_id="odd\"id",type="bug",status="new",source="active"} 0` + "\n",
+		`conductor_tracks{status="in_progress"} 1` + "\n",
+		`conductor_tracks{status="new"} 1` + "\n",
+		"conductor_tasks 4\n",
+		"conductor_tasks_done 2\n",
+		"conductor_phases 2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `conductor_track_seconds_since_update{track_id="odd`) {
+		t.Error("tracks without updated_at should have no staleness sample")
+	}
+	if strings.Contains(out, "conductor_track_completed_timestamp_seconds{") {
+		t.Error("tracks without completed_at should have no completion sample")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Handler("../../testdata/discovery").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), `conductor_track_tasks_done{track_id="feature-alpha_20260101"`) {
+		t.Errorf("missing alpha sample:\n%s", rec.Body.String())
+	}
+}
@@ -0,0 +1,225 @@
+// Package revert builds and executes git revert plans for a Conductor
+// track, phase or task, mirroring the /conductor:revert skill.
+package revert
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/git"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Target identifies the unit of work to revert. PhaseIdx -1 selects the
+// whole track; TaskIdx -1 selects the whole phase. Both index into the
+// track's parsed Phases and Tasks slices.
+type Target struct {
+	Track    conductor.Track
+	Dir      string // track directory containing plan.md
+	PhaseIdx int
+	TaskIdx  int
+}
+
+// Describe returns a human-readable label such as "Task 'Add deps'".
+func (t Target) Describe() string {
+	switch {
+	case t.PhaseIdx < 0:
+		return fmt.Sprintf("Track '%s'", t.Track.TrackID)
+	case t.TaskIdx < 0:
+		p := t.Track.Phases[t.PhaseIdx]
+		return fmt.Sprintf("Phase %d '%s'", p.Number, p.Name)
+	default:
+		return fmt.Sprintf("Task '%s'", t.Track.Phases[t.PhaseIdx].Tasks[t.TaskIdx].Name)
+	}
+}
+
+// Commit is a commit selected for reverting.
+type Commit struct {
+	SHA     string
+	Subject string
+	Time    time.Time
+	Reason  string // "task", "checkpoint", "plan update", "track"
+	Merge   bool
+}
+
+// Plan is an ordered revert plan. Commits are listed in the order they
+// must be reverted: newest first.
+type Plan struct {
+	Target   string
+	Commits  []Commit
+	Missing  []string // SHAs recorded in plan.md that git does not know
+	Warnings []string
+}
+
+// Build investigates git history for the target and returns a revert
+// plan. It collects the implementation commits recorded in Task.Commit and
+// Phase.Checkpoint, the commits that changed the target's plan.md lines
+// (except the one that first added them), and for a whole track every
+// commit touching the track directory and its registry entry.
+func Build(t Target) (Plan, error) {
+	if t.PhaseIdx >= len(t.Track.Phases) ||
+		(t.PhaseIdx >= 0 && t.TaskIdx >= len(t.Track.Phases[t.PhaseIdx].Tasks)) {
+		return Plan{}, fmt.Errorf("target out of range")
+	}
+	plan := Plan{Target: t.Describe()}
+	if _, err := git.Root(t.Dir); err != nil {
+		return plan, err
+	}
+
+	reasons := map[string]string{}
+	add := func(sha, reason string) {
+		if _, ok := reasons[sha]; !ok {
+			reasons[sha] = reason
+		}
+	}
+
+	// Implementation commits recorded in the plan.
+	var phases []conductor.Phase
+	if t.PhaseIdx < 0 {
+		phases = t.Track.Phases
+	} else {
+		phases = t.Track.Phases[t.PhaseIdx : t.PhaseIdx+1]
+	}
+	var recorded []struct{ sha, reason string }
+	var patterns []string
+	for _, p := range phases {
+		tasks := p.Tasks
+		if t.TaskIdx >= 0 {
+			tasks = tasks[t.TaskIdx : t.TaskIdx+1]
+		} else {
+			if p.Checkpoint != "" {
+				recorded = append(recorded, struct{ sha, reason string }{p.Checkpoint, "checkpoint"})
+			}
+			patterns = append(patterns, fmt.Sprintf(`^## Phase %d: %s([[:space:]]*\[checkpoint:[[:space:]]*[a-f0-9]+\])?[[:space:]]*$`,
+				p.Number, regexp.QuoteMeta(p.Name)))
+		}
+		for _, task := range tasks {
+			if task.Commit != "" {
+				recorded = append(recorded, struct{ sha, reason string }{task.Commit, "task"})
+			}
+			// Anchored as in conductor.TaskRe, so that "Add auth" does not
+			// also match "Add auth middleware".
+			patterns = append(patterns, `^- \[[ x~]\] Task: `+regexp.QuoteMeta(task.Name)+
+				"([[:space:]]+`[a-f0-9]{7,}`)?[[:space:]]*$")
+		}
+	}
+	for _, r := range recorded {
+		full, err := git.Run(t.Dir, "rev-parse", "--verify", "--quiet", r.sha+"^{commit}")
+		if err != nil {
+			plan.Missing = append(plan.Missing, r.sha)
+			continue
+		}
+		add(strings.TrimSpace(full), r.reason)
+	}
+
+	if t.PhaseIdx < 0 {
+		// Every commit that touched the track, including its creation.
+		out, err := git.Run(t.Dir, "log", "--format=%H", "--", ".")
+		if err != nil {
+			return plan, err
+		}
+		for _, sha := range strings.Fields(out) {
+			add(sha, "track")
+		}
+		registry := filepath.Join(t.Dir, "..", "..", "tracks.md")
+		out, err = git.Run(t.Dir, "log", "--format=%H", "-E",
+			"-G", regexp.QuoteMeta(t.Track.TrackID+"/"), "--", registry)
+		if err == nil {
+			for _, sha := range strings.Fields(out) {
+				add(sha, "track")
+			}
+		}
+	} else {
+		// Plan updates that touched the target's lines, excluding the
+		// commit that introduced them (which usually holds sibling lines).
+		for _, pat := range patterns {
+			out, err := git.Run(t.Dir, "log", "--format=%H", "-E", "-G", pat, "--", "plan.md")
+			if err != nil {
+				return plan, err
+			}
+			shas := strings.Fields(out)
+			if len(shas) > 0 {
+				shas = shas[:len(shas)-1]
+			}
+			for _, sha := range shas {
+				add(sha, "plan update")
+			}
+		}
+	}
+
+	if len(reasons) == 0 {
+		return plan, nil
+	}
+
+	shas := make([]string, 0, len(reasons))
+	for sha := range reasons {
+		shas = append(shas, sha)
+	}
+	sort.Strings(shas)
+	// --no-walk=sorted lists the commits newest first: the revert order.
+	args := append([]string{"log", "--no-walk=sorted", "--format=%H%x09%cI%x09%P%x09%s"}, shas...)
+	out, err := git.Run(t.Dir, args...)
+	if err != nil {
+		return plan, err
+	}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		f := strings.SplitN(line, "\t", 4)
+		if len(f) != 4 {
+			continue
+		}
+		when, _ := time.Parse(time.RFC3339, f[1])
+		c := Commit{
+			SHA:     f[0],
+			Time:    when,
+			Subject: f[3],
+			Reason:  reasons[f[0]],
+			Merge:   len(strings.Fields(f[2])) > 1,
+		}
+		if c.Merge {
+			plan.Warnings = append(plan.Warnings,
+				fmt.Sprintf("%s is a merge commit; it will be reverted against its first parent", c.SHA[:7]))
+		}
+		plan.Commits = append(plan.Commits, c)
+	}
+	return plan, nil
+}
+
+// Execute runs git revert --no-edit for every commit in the plan, newest
+// first. It stops at the first failure and returns an error explaining
+// how to resolve or abort the in-progress revert.
+func Execute(dir string, plan Plan) error {
+	for i, c := range plan.Commits {
+		args := []string{"revert", "--no-edit"}
+		if c.Merge {
+			args = append(args, "-m", "1")
+		}
+		args = append(args, c.SHA)
+		if _, err := git.Run(dir, args...); err != nil {
+			return fmt.Errorf("reverting %s (%d of %d) failed: %w; resolve the conflicts and run "+
+				"`git revert --continue`, or `git revert --abort` to undo",
+				c.SHA[:7], i+1, len(plan.Commits), err)
+		}
+	}
+	return nil
+}
+
+// Format renders the plan as plain text for the CLI.
+func Format(plan Plan) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Target: Revert %s\n", plan.Target)
+	fmt.Fprintf(&b, "Commits to revert: %d\n", len(plan.Commits))
+	for _, c := range plan.Commits {
+		fmt.Fprintf(&b, "  - %s %s %s (%s)\n", c.SHA[:7], c.Time.Format("2006-01-02"), c.Subject, c.Reason)
+	}
+	for _, sha := range plan.Missing {
+		fmt.Fprintf(&b, "Warning: commit %s recorded in plan.md was not found in git history\n", sha)
+	}
+	for _, w := range plan.Warnings {
+		fmt.Fprintf(&b, "Warning: %s\n", w)
+	}
+	return b.String()
+}
@@ -0,0 +1,231 @@
+package revert
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// fixture is a temporary repository holding one track with two tasks,
+// each implemented and then checked off in a separate plan-update commit.
+type fixture struct {
+	root     string
+	trackDir string
+	clock    time.Time
+	shas     map[string]string // commit message -> full SHA
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	f := &fixture{
+		root:  t.TempDir(),
+		clock: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+		shas:  map[string]string{},
+	}
+	f.trackDir = filepath.Join(f.root, "conductor", "tracks", "demo_20260301")
+	f.git(t, "init", "-q")
+	// Execute runs git without the test identity environment.
+	f.git(t, "config", "user.name", "Test")
+	f.git(t, "config", "user.email", "test@example.com")
+
+	f.write(t, "conductor/tracks.md", "# Project Tracks\n\n---\n\n- [ ] **Track: Demo**\n  *Link: [./tracks/demo_20260301/](./tracks/demo_20260301/)*\n")
+	f.write(t, "conductor/tracks/demo_20260301/metadata.json", `{"track_id": "demo_20260301", "status": "new"}`)
+	f.write(t, "conductor/tracks/demo_20260301/plan.md", "## Phase 1: Build\n\n- [ ] Task: First\n- [ ] Task: Second\n")
+	f.commit(t, "chore(conductor): add track")
+
+	f.write(t, "first.txt", "first\n")
+	f.commit(t, "feat: first")
+	f.write(t, "conductor/tracks/demo_20260301/plan.md",
+		fmt.Sprintf("## Phase 1: Build\n\n- [x] Task: First `%s`\n- [ ] Task: Second\n", f.shas["feat: first"][:7]))
+	f.commit(t, "conductor(plan): mark first complete")
+
+	f.write(t, "second.txt", "second\n")
+	f.commit(t, "feat: second")
+	f.write(t, "conductor/tracks/demo_20260301/plan.md",
+		fmt.Sprintf("## Phase 1: Build [checkpoint: %s]\n\n- [x] Task: First `%s`\n- [x] Task: Second `%s`\n",
+			f.shas["feat: second"][:7], f.shas["feat: first"][:7], f.shas["feat: second"][:7]))
+	f.commit(t, "conductor(plan): mark second complete")
+	return f
+}
+
+func (f *fixture) write(t *testing.T, rel, content string) {
+	t.Helper()
+	path := filepath.Join(f.root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (f *fixture) git(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = f.root
+	date := f.clock.Format(time.RFC3339)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com",
+		"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date,
+	)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// commit stages everything and commits one minute after the last commit.
+func (f *fixture) commit(t *testing.T, msg string) {
+	t.Helper()
+	f.clock = f.clock.Add(time.Minute)
+	f.git(t, "add", "-A")
+	f.git(t, "commit", "-q", "-m", msg)
+	f.shas[msg] = f.git(t, "rev-parse", "HEAD")
+}
+
+func (f *fixture) track(t *testing.T) conductor.Track {
+	t.Helper()
+	for _, tr := range conductor.DiscoverTracks(f.root) {
+		if tr.TrackID == "demo_20260301" {
+			return tr
+		}
+	}
+	t.Fatal("fixture track not found")
+	return conductor.Track{}
+}
+
+func subjects(plan Plan) []string {
+	var s []string
+	for _, c := range plan.Commits {
+		s = append(s, c.Subject)
+	}
+	return s
+}
+
+func TestBuild_Task(t *testing.T) {
+	f := newFixture(t)
+	plan, err := Build(Target{Track: f.track(t), Dir: f.trackDir, PhaseIdx: 0, TaskIdx: 0})
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+
+	// The later plan update leaves the First line unchanged, so only the
+	// commit that checked First off is included.
+	want := []string{
+		"conductor(plan): mark first complete",
+		"feat: first",
+	}
+	if got := subjects(plan); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("commits = %v, want %v", got, want)
+	}
+	if plan.Commits[0].Reason != "plan update" || plan.Commits[1].Reason != "task" {
+		t.Errorf("reasons = %q, %q; want plan update, task", plan.Commits[0].Reason, plan.Commits[1].Reason)
+	}
+	if plan.Target != "Task 'First'" {
+		t.Errorf("Target = %q", plan.Target)
+	}
+}
+
+func TestBuild_TaskIgnoresLongerNamedSibling(t *testing.T) {
+	f := newFixture(t)
+	plan := "## Phase 1: Build [checkpoint: %s]\n\n- [x] Task: First `%s`\n- [x] Task: Second `%s`\n- [ ] Task: First aid\n"
+	f.write(t, "conductor/tracks/demo_20260301/plan.md",
+		fmt.Sprintf(plan, f.shas["feat: second"][:7], f.shas["feat: first"][:7], f.shas["feat: second"][:7]))
+	f.commit(t, "conductor(plan): add first aid")
+
+	got, err := Build(Target{Track: f.track(t), Dir: f.trackDir, PhaseIdx: 0, TaskIdx: 0})
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	for _, s := range subjects(got) {
+		if s == "conductor(plan): add first aid" {
+			t.Errorf("commits = %v, should not include the commit adding task 'First aid'", subjects(got))
+		}
+	}
+}
+
+func TestBuild_PhaseIncludesCheckpoint(t *testing.T) {
+	f := newFixture(t)
+	plan, err := Build(Target{Track: f.track(t), Dir: f.trackDir, PhaseIdx: 0, TaskIdx: -1})
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	got := strings.Join(subjects(plan), "|")
+	for _, want := range []string{"feat: first", "feat: second", "mark first complete", "mark second complete"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("phase plan missing %q: %v", want, got)
+		}
+	}
+	if strings.Contains(got, "add track") {
+		t.Error("phase revert must not include the track creation commit")
+	}
+}
+
+func TestBuild_TrackIncludesCreation(t *testing.T) {
+	f := newFixture(t)
+	plan, err := Build(Target{Track: f.track(t), Dir: f.trackDir, PhaseIdx: -1, TaskIdx: -1})
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if len(plan.Commits) != 5 {
+		t.Fatalf("got %d commits, want 5: %v", len(plan.Commits), subjects(plan))
+	}
+	if plan.Commits[4].Subject != "chore(conductor): add track" {
+		t.Errorf("oldest commit = %q, want track creation", plan.Commits[4].Subject)
+	}
+}
+
+func TestBuild_MissingCommit(t *testing.T) {
+	f := newFixture(t)
+	track := f.track(t)
+	track.Phases[0].Tasks[1].Commit = "deadbee"
+	plan, err := Build(Target{Track: track, Dir: f.trackDir, PhaseIdx: 0, TaskIdx: 1})
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if len(plan.Missing) != 1 || plan.Missing[0] != "deadbee" {
+		t.Errorf("Missing = %v, want [deadbee]", plan.Missing)
+	}
+	if !strings.Contains(Format(plan), "deadbee") {
+		t.Error("formatted plan should warn about the missing commit")
+	}
+}
+
+func TestExecute_RevertsTask(t *testing.T) {
+	f := newFixture(t)
+	plan, err := Build(Target{Track: f.track(t), Dir: f.trackDir, PhaseIdx: 0, TaskIdx: 1})
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if err := Execute(f.trackDir, plan); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(f.root, "second.txt")); !os.IsNotExist(err) {
+		t.Error("second.txt should be removed by the revert")
+	}
+	if _, err := os.Stat(filepath.Join(f.root, "first.txt")); err != nil {
+		t.Error("first.txt should be untouched")
+	}
+	planData, _ := os.ReadFile(filepath.Join(f.trackDir, "plan.md"))
+	if !strings.Contains(string(planData), "- [ ] Task: Second") {
+		t.Errorf("plan should show Second as pending again:\n%s", planData)
+	}
+}
+
+func TestBuild_OutOfRange(t *testing.T) {
+	f := newFixture(t)
+	if _, err := Build(Target{Track: f.track(t), Dir: f.trackDir, PhaseIdx: 3, TaskIdx: -1}); err == nil {
+		t.Error("expected error for out-of-range phase")
+	}
+}
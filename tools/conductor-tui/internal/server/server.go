@@ -0,0 +1,144 @@
+// Package server exposes discovered tracks over a read-only JSON API and
+// an embedded HTML dashboard with server-sent live updates.
+package server
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+//go:embed static/index.html
+var static embed.FS
+
+// Track is the API representation of a track: its metadata, phases and
+// tasks plus task progress.
+type Track struct {
+	conductor.Track
+	Progress conductor.Progress `json:"progress"`
+	Percent  int                `json:"percent"`
+}
+
+// Server serves the API and dashboard for the project at BasePath.
+type Server struct {
+	BasePath string
+	// Interval is how often the event stream re-scans the tracks.
+	Interval time.Duration
+
+	mux *http.ServeMux
+}
+
+// New returns a Server for basePath with the routes registered.
+func New(basePath string) *Server {
+	s := &Server{BasePath: basePath, Interval: 2 * time.Second, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /api/tracks", s.handleTracks)
+	s.mux.HandleFunc("GET /api/tracks/{id}", s.handleTrack)
+	s.mux.HandleFunc("GET /api/events", s.handleEvents)
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	return s
+}
+
+// Handle registers an additional handler, e.g. an opt-in endpoint.
+func (s *Server) Handle(pattern string, h http.Handler) {
+	s.mux.Handle(pattern, h)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func toAPI(t conductor.Track) Track {
+	// Empty phases list their tasks as [] rather than null.
+	t.Phases = slices.Clone(t.Phases)
+	for i := range t.Phases {
+		if t.Phases[i].Tasks == nil {
+			t.Phases[i].Tasks = []conductor.Task{}
+		}
+	}
+	p := conductor.TrackProgress(t)
+	return Track{Track: t, Progress: p, Percent: p.Percent()}
+}
+
+// tracks discovers the tracks, filtered by the optional status, type and
+// archived query parameters.
+func (s *Server) tracks(r *http.Request) []Track {
+	q := r.URL.Query()
+	statuses := util.SplitList(q.Get("status"))
+	types := util.SplitList(q.Get("type"))
+	archived := q.Get("archived") == "true" || q.Get("archived") == "1"
+
+	out := []Track{}
+	for _, t := range conductor.New(s.BasePath).Tracks(conductor.Filter{Statuses: statuses, Types: types, Archived: archived}) {
+		out = append(out, toAPI(t))
+	}
+	return out
+}
+
+func (s *Server) handleTracks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.tracks(r))
+}
+
+func (s *Server) handleTrack(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if t, err := conductor.New(s.BasePath).Track(id); err == nil {
+		writeJSON(w, http.StatusOK, toAPI(t))
+		return
+	}
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("track %q not found", id)})
+}
+
+// handleEvents streams the track list as a server-sent "tracks" event on
+// connect and again whenever it changes.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	var last []byte
+	for {
+		payload, err := json.Marshal(s.tracks(r))
+		if err == nil && !bytes.Equal(payload, last) {
+			fmt.Fprintf(w, "event: tracks\ndata: %s\n\n", payload)
+			flusher.Flush()
+			last = payload
+		}
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	page, err := static.ReadFile("static/index.html")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(page)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
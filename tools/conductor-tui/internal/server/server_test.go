@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const discoveryPath = "../../testdata/discovery"
+
+func get(t *testing.T, h http.Handler, url string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	return rec
+}
+
+func TestTracks_List(t *testing.T) {
+	rec := get(t, New(discoveryPath), "/api/tracks")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var tracks []Track
+	if err := json.Unmarshal(rec.Body.Bytes(), &tracks); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(tracks) != 2 {
+		t.Fatalf("got %d tracks, want 2 active", len(tracks))
+	}
+	alpha := tracks[1] // newest first
+	if alpha.TrackID != "feature-alpha_20260101" || len(alpha.Phases) != 1 || len(alpha.Phases[0].Tasks) != 2 {
+		t.Errorf("alpha = %+v, want one phase with two tasks", alpha)
+	}
+	if alpha.Progress.Done != 1 || alpha.Percent != 50 {
+		t.Errorf("alpha progress = %+v (%d%%)", alpha.Progress, alpha.Percent)
+	}
+}
+
+func TestTracks_Filters(t *testing.T) {
+	rec := get(t, New(discoveryPath), "/api/tracks?archived=true&type=feature")
+	var tracks []Track
+	if err := json.Unmarshal(rec.Body.Bytes(), &tracks); err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 2 || tracks[0].TrackID != "feature-alpha_20260101" || tracks[1].TrackID != "feature-gamma_20250601" {
+		t.Errorf("got %d tracks, want alpha and archived gamma", len(tracks))
+	}
+
+	rec = get(t, New(discoveryPath), "/api/tracks?status=blocked")
+	if strings.TrimSpace(rec.Body.String()) != "[]" {
+		t.Errorf("empty result should be [], got %s", rec.Body.String())
+	}
+}
+
+func TestTrack_ByID(t *testing.T) {
+	s := New(discoveryPath)
+	rec := get(t, s, "/api/tracks/feature-gamma_20250601")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	var track Track
+	if err := json.Unmarshal(rec.Body.Bytes(), &track); err != nil {
+		t.Fatal(err)
+	}
+	if track.Source != "archived" {
+		t.Errorf("Source = %q, want archived tracks reachable by ID", track.Source)
+	}
+
+	rec = get(t, s, "/api/tracks/missing")
+	if rec.Code != http.StatusNotFound || !strings.Contains(rec.Body.String(), `"error"`) {
+		t.Errorf("missing track: status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTrack_EmptyPhase(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "conductor", "tracks", "fresh_20260301")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "metadata.json"), []byte(`{"track_id": "fresh_20260301", "status": "new"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "plan.md"), []byte("# Plan\n\n## Phase 1: Implementation\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The dashboard iterates over tasks, so they must not be null.
+	type phase struct {
+		Tasks json.RawMessage `json:"tasks"`
+	}
+	var tracks []struct{ Phases []phase }
+	rec := get(t, New(base), "/api/tracks")
+	if err := json.Unmarshal(rec.Body.Bytes(), &tracks); err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 1 || len(tracks[0].Phases) != 1 || string(tracks[0].Phases[0].Tasks) != "[]" {
+		t.Errorf("empty phase should list tasks as [], got %s", rec.Body.String())
+	}
+	var track struct{ Phases []phase }
+	rec = get(t, New(base), "/api/tracks/fresh_20260301")
+	if err := json.Unmarshal(rec.Body.Bytes(), &track); err != nil {
+		t.Fatal(err)
+	}
+	if len(track.Phases) != 1 || string(track.Phases[0].Tasks) != "[]" {
+		t.Errorf("empty phase should list tasks as [], got %s", rec.Body.String())
+	}
+}
+
+func TestIndex(t *testing.T) {
+	s := New(discoveryPath)
+	rec := get(t, s, "/")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "EventSource") {
+		t.Errorf("dashboard not served: %d", rec.Code)
+	}
+	if rec := get(t, s, "/nope"); rec.Code != http.StatusNotFound {
+		t.Errorf("unknown path status = %d, want 404", rec.Code)
+	}
+}
+
+func TestEvents_StreamsChanges(t *testing.T) {
+	base := t.TempDir()
+	dir := filepath.Join(base, "conductor", "tracks", "live_20260301")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	meta := filepath.Join(dir, "metadata.json")
+	if err := os.WriteFile(meta, []byte(`{"track_id": "live_20260301", "status": "new"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := New(base)
+	s.Interval = 20 * time.Millisecond
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	events := make(chan string)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		sc.Buffer(nil, 1<<20)
+		for sc.Scan() {
+			if data, ok := strings.CutPrefix(sc.Text(), "data: "); ok {
+				events <- data
+			}
+		}
+		close(events)
+	}()
+
+	first := <-events
+	if !strings.Contains(first, `"status":"new"`) {
+		t.Fatalf("first event = %s", first)
+	}
+	if err := os.WriteFile(meta, []byte(`{"track_id": "live_20260301", "status": "in_progress"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case next := <-events:
+		if !strings.Contains(next, `"status":"in_progress"`) {
+			t.Errorf("second event = %s", next)
+		}
+	case <-ctx.Done():
+		t.Fatal("no event after the track changed")
+	}
+}
@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"cmp"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// EditorFiles maps the keys that open a track file in the external editor
+// to the file they open.
+var EditorFiles = map[string]string{
+	"P": "plan.md",
+	"S": "spec.md",
+	"M": "metadata.json",
+}
+
+// EditorClosedMsg reports that the external editor opened on a file of
+// Track has exited.
+type EditorClosedMsg struct {
+	Track conductor.Track
+	Err   error
+}
+
+// TrackReloadedMsg carries a track re-read from disk, replacing the
+// loaded track with the same ID.
+type TrackReloadedMsg struct {
+	Track conductor.Track
+	Err   error
+}
+
+// defaultEditor is used when neither $VISUAL nor $EDITOR is set.
+var defaultEditor = "vi"
+
+func init() {
+	if runtime.GOOS == "windows" {
+		defaultEditor = "notepad"
+	}
+}
+
+// editorArgs returns the command line opening path in editor, which may
+// carry its own arguments ("code --wait"). When line is positive and the
+// editor is one known to accept it, the file is opened at that line.
+func editorArgs(editor, path string, line int) []string {
+	args := strings.Fields(editor)
+	if line <= 0 {
+		return append(args, path)
+	}
+	n := strconv.Itoa(line)
+	switch strings.TrimSuffix(filepath.Base(args[0]), ".exe") {
+	case "vi", "vim", "nvim", "gvim", "mvim", "view", "nano", "emacs", "emacsclient", "micro", "kak", "joe", "ne":
+		return append(args, "+"+n, path)
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return append(args, "--goto", path+":"+n)
+	case "subl", "hx", "helix", "zed":
+		return append(args, path+":"+n)
+	}
+	return append(args, path)
+}
+
+// editorCommand returns the command opening path in $VISUAL, $EDITOR or
+// the platform default, at line where supported.
+func editorCommand(path string, line int) *exec.Cmd {
+	editor := strings.TrimSpace(cmp.Or(os.Getenv("VISUAL"), os.Getenv("EDITOR")))
+	args := editorArgs(cmp.Or(editor, defaultEditor), path, line)
+	return exec.Command(args[0], args[1:]...)
+}
+
+// OpenInEditor returns a command that suspends the TUI and opens the file
+// name of the track at the given filtered index in the external editor,
+// at line of plan.md when positive.
+func (m Model) OpenInEditor(filteredIdx int, name string, line int) tea.Cmd {
+	tracks := m.Tracks()
+	if filteredIdx >= len(tracks) {
+		return nil
+	}
+	track := tracks[filteredIdx]
+	cmd := editorCommand(filepath.Join(m.TrackDir(filteredIdx), name), line)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return EditorClosedMsg{Track: track, Err: err}
+	})
+}
+
+// ReloadTrack returns a command that re-reads t from disk.
+func (m Model) ReloadTrack(t conductor.Track) tea.Cmd {
+	dir := m.Project(t).Dir(t)
+	return func() tea.Msg {
+		track, err := conductor.LoadTrack(dir, t.Source)
+		return TrackReloadedMsg{Track: track, Err: err}
+	}
+}
+
+// editorTarget returns the track under the cursor and the plan.md line of
+// the phase, task or sub-task selected on the current screen, or 0 when
+// nothing more specific than the track is selected.
+func (m Model) editorTarget() (int, int, bool) {
+	s, tracks := m.CurrentScreen(), m.Tracks()
+	if s.ScreenType == ScreenTracks {
+		return s.Cursor, 0, s.Cursor < len(tracks)
+	}
+	if s.TrackIdx >= len(tracks) {
+		return 0, 0, false
+	}
+	phases := tracks[s.TrackIdx].Phases
+	switch s.ScreenType {
+	case ScreenPhases:
+		if s.Cursor < len(phases) {
+			return s.TrackIdx, phases[s.Cursor].Line, true
+		}
+	case ScreenTasks:
+		if s.PhaseIdx < len(phases) && s.Cursor < len(phases[s.PhaseIdx].Tasks) {
+			return s.TrackIdx, phases[s.PhaseIdx].Tasks[s.Cursor].Line, true
+		}
+	case ScreenDetail:
+		if s.PhaseIdx < len(phases) && s.TaskIdx < len(phases[s.PhaseIdx].Tasks) {
+			task := phases[s.PhaseIdx].Tasks[s.TaskIdx]
+			if s.Cursor < len(task.SubTasks) {
+				return s.TrackIdx, task.SubTasks[s.Cursor].Line, true
+			}
+			return s.TrackIdx, task.Line, true
+		}
+	case ScreenEdit:
+		return s.TrackIdx, 0, true
+	default:
+		return 0, 0, false
+	}
+	return s.TrackIdx, 0, true
+}
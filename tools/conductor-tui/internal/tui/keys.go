@@ -1,13 +1,33 @@
 package tui
 
 import (
+	"slices"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/data"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/history"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/revert"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
 )
 
 // HandleKey processes key messages and returns the updated model and command.
 func (m Model) HandleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s, tracks := m.CurrentScreen(), m.Tracks()
+	result, cmd := m.handleKey(msg)
+	next := result.(Model)
+	switch {
+	case s.ScreenType == ScreenTracks && len(next.Stack) > len(m.Stack) && s.Cursor < len(tracks):
+		next.FilterPin = tracks[s.Cursor].TrackID
+	case len(next.Stack) == 1 && next.FilterPin != "":
+		// Back on the list: drop the pin and keep the cursor in range.
+		next.FilterPin = ""
+		next.MoveCursor(0)
+	}
+	return next, cmd
+}
+
+func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	s := m.CurrentScreen()
 
 	// Quit confirmation screen
@@ -22,6 +42,97 @@ func (m Model) HandleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Revert confirmation prompt
+	if s.ScreenType == ScreenRevert && s.Confirming {
+		sp := &m.Stack[len(m.Stack)-1]
+		switch msg.String() {
+		case "y":
+			sp.Confirming = false
+			m.RevertRunning = true
+			return m, m.ExecuteRevert()
+		case "n", "esc":
+			sp.Confirming = false
+		}
+		return m, nil
+	}
+
+	if s.ScreenType == ScreenNewTrack {
+		return m.handleNewTrackKey(msg)
+	}
+
+	if s.ScreenType == ScreenRename {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.Stack = m.Stack[:len(m.Stack)-1]
+		case tea.KeyEnter:
+			m.RenameErr = nil
+			return m, m.RenameTrack(s.TrackIdx)
+		default:
+			editText(&m.RenameID, msg)
+		}
+		return m, nil
+	}
+
+	// Backward status move confirmation
+	if s.ScreenType == ScreenEdit && s.Confirming {
+		sp := &m.Stack[len(m.Stack)-1]
+		switch msg.String() {
+		case "y":
+			sp.Confirming = false
+			if m.setStatus(m.PendingStatus) {
+				m.saveCurrentTrack()
+			}
+		case "n", "esc":
+			sp.Confirming = false
+		}
+		return m, nil
+	}
+
+	if s.ScreenType == ScreenEdit && s.Editing && m.editField().Kind != conductor.KindEnum {
+		return m.handleFieldInputKey(msg)
+	}
+
+	// Archive confirmation
+	if s.ScreenType == ScreenArchive {
+		switch msg.String() {
+		case "y":
+			m.ArchiveErr = nil
+			return m, m.MoveTrack(s.TrackIdx)
+		case "n", "esc":
+			m.Stack = m.Stack[:len(m.Stack)-1]
+		case "left":
+			m.ArchiveStatus = CycleValue(m.archiveStatuses(), m.ArchiveStatus, -1)
+		case "right":
+			m.ArchiveStatus = CycleValue(m.archiveStatuses(), m.ArchiveStatus, 1)
+		case "up":
+			m.MoveCursor(-1)
+		case "down":
+			m.MoveCursor(1)
+		}
+		return m, nil
+	}
+
+	// Plan editing on the phases, tasks and detail screens
+	if isPlanScreen(s.ScreenType) && m.Branch == "" {
+		switch {
+		case s.Editing:
+			return m.handlePlanInputKey(msg)
+		case s.Confirming:
+			sp := &m.Stack[len(m.Stack)-1]
+			switch msg.String() {
+			case "y":
+				sp.Confirming = false
+				m.deletePlanItem()
+			case "n", "esc":
+				sp.Confirming = false
+			}
+			return m, nil
+		}
+		if next, ok := m.handlePlanKey(msg); ok {
+			return next, nil
+		}
+	}
+
 	tracks := m.Tracks()
 
 	switch msg.String() {
@@ -44,18 +155,27 @@ func (m Model) HandleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				sp.Editing = false
 			} else {
 				sp.Editing = true
+				if f := m.editField(); f.Kind != conductor.KindEnum && s.TrackIdx < len(tracks) {
+					m.FieldInput = NewTextArea(f.Format(tracks[s.TrackIdx].Value(f.Key)), m.descWidth())
+					m.FieldErr = nil
+				}
 			}
+		} else if s.ScreenType == ScreenBranches {
+			m.selectBranch(s.Cursor)
+			return m, m.LoadTracks()
+		} else if s.ScreenType == ScreenExport {
+			m.ExportPath = ""
+			m.ExportErr = nil
+			return m, m.Export(s)
 		} else {
 			m.handleEnter(tracks)
 		}
 	case "right":
-		if s.ScreenType == ScreenEdit && m.CurrentScreen().Editing {
-			m.cycleEditField(1)
+		if s.ScreenType == ScreenEdit && m.CurrentScreen().Editing && m.cycleEditField(1) {
 			m.saveCurrentTrack()
 		}
 	case "left":
-		if s.ScreenType == ScreenEdit && m.CurrentScreen().Editing {
-			m.cycleEditField(-1)
+		if s.ScreenType == ScreenEdit && m.CurrentScreen().Editing && m.cycleEditField(-1) {
 			m.saveCurrentTrack()
 		}
 	case "esc":
@@ -72,12 +192,117 @@ func (m Model) HandleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.ShowArchived = !m.ShowArchived
 			m.Stack = []Screen{{ScreenType: ScreenTracks}}
 		}
-	case "e":
+	case "b":
+		if (s.ScreenType == ScreenTasks || s.ScreenType == ScreenDetail) && m.Branch == "" {
+			m.ShowBlame = !m.ShowBlame
+			if m.ShowBlame {
+				return m, m.LoadBlame(s.TrackIdx)
+			}
+			return m, nil
+		}
 		if s.ScreenType == ScreenTracks {
+			cursor := 0
+			for i, ref := range m.Branches {
+				if ref == m.Branch {
+					cursor = i + 1
+				}
+			}
+			m.Stack = append(m.Stack, Screen{ScreenType: ScreenBranches, Cursor: cursor})
+			return m, m.LoadBranches()
+		}
+	case "r":
+		if m.Branch == "" {
+			if target, ok := m.revertScreen(s, tracks); ok {
+				m.RevertPlan = revert.Plan{}
+				m.RevertLoaded = false
+				m.RevertDone = false
+				m.RevertErr = nil
+				m.Stack = append(m.Stack, target)
+				return m, m.BuildRevertPlan()
+			}
+		}
+	case "x":
+		if s.ScreenType == ScreenTracks && len(tracks) > 0 {
+			m.ExportAll = true
+			m.ExportPath = ""
+			m.ExportErr = nil
+			m.Stack = append(m.Stack, Screen{ScreenType: ScreenExport, TrackIdx: s.Cursor})
+			return m, nil
+		}
+		if s.ScreenType == ScreenRevert && m.RevertLoaded && !m.RevertDone &&
+			!m.RevertRunning && len(m.RevertPlan.Commits) > 0 {
+			m.Stack[len(m.Stack)-1].Confirming = true
+		}
+	case "t":
+		if s.ScreenType == ScreenExport {
+			m.ExportAll = !m.ExportAll
+		}
+		if s.ScreenType == ScreenTracks {
+			m.TypeFilter = CycleValue(append([]string{""}, m.Types()...), m.TypeFilter, 1)
+			m.Stack = []Screen{{ScreenType: ScreenTracks}}
+		}
+	case "s":
+		if s.ScreenType == ScreenTracks {
+			m.StatusFilter = CycleValue(append([]string{""}, m.Statuses()...), m.StatusFilter, 1)
+			m.Stack = []Screen{{ScreenType: ScreenTracks}}
+		}
+	case "l":
+		if s.ScreenType == ScreenTracks {
+			m.Stack = append(m.Stack, Screen{ScreenType: ScreenLog})
+		}
+	case "w":
+		if s.ScreenType == ScreenTracks {
+			m.MergeWorktrees = !m.MergeWorktrees
+			m.Branch = ""
+			m.EventTracks = nil
+			m.Stack = []Screen{{ScreenType: ScreenTracks}}
+			return m, m.LoadTracks()
+		}
+	case "A":
+		if s.ScreenType == ScreenTracks && m.Branch == "" && s.Cursor < len(tracks) {
+			t := tracks[s.Cursor]
+			m.ArchiveFiles, m.ArchiveErr = m.Project(t).TrackFiles(t)
+			m.ArchiveStatus = ""
+			m.Stack = append(m.Stack, Screen{ScreenType: ScreenArchive, TrackIdx: s.Cursor})
+		}
+	case "R":
+		if s.ScreenType == ScreenTracks && m.Branch == "" && s.Cursor < len(tracks) {
+			m.RenameID = tracks[s.Cursor].TrackID
+			m.RenameErr = nil
+			m.Stack = append(m.Stack, Screen{ScreenType: ScreenRename, TrackIdx: s.Cursor})
+		}
+	case "n":
+		if s.ScreenType == ScreenTracks && m.Branch == "" {
+			m.NewTrack = NewTrackForm{Type: m.Types()[0]}
+			m.NewTrackErr = nil
+			m.Stack = append(m.Stack, Screen{ScreenType: ScreenNewTrack})
+		}
+	case "e":
+		// Tracks read from another branch are read-only.
+		if s.ScreenType == ScreenTracks && m.Branch == "" {
 			if len(tracks) > 0 && s.Cursor < len(tracks) {
 				m.Stack = append(m.Stack, Screen{ScreenType: ScreenEdit, TrackIdx: s.Cursor})
 			}
 		}
+	case "P", "S", "M":
+		// Only plan.md is opened at the selected item's line.
+		if m.Branch == "" && !s.Editing {
+			if idx, line, ok := m.editorTarget(); ok {
+				if msg.String() != "P" {
+					line = 0
+				}
+				m.EditorErr = nil
+				return m, m.OpenInEditor(idx, EditorFiles[msg.String()], line)
+			}
+		}
+	case "h":
+		if s.ScreenType == ScreenPhases && s.TrackIdx < len(tracks) {
+			m.History = history.Report{}
+			m.HistoryTrack = ""
+			m.HistoryErr = nil
+			m.Stack = append(m.Stack, Screen{ScreenType: ScreenHistory, TrackIdx: s.TrackIdx})
+			return m, m.LoadHistory(s.TrackIdx)
+		}
 	case "q":
 		if s.ScreenType == ScreenTracks {
 			m.Stack = append(m.Stack, Screen{ScreenType: ScreenQuit})
@@ -86,37 +311,202 @@ func (m Model) HandleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// cycleEditField cycles the value of the currently selected edit field.
-func (m *Model) cycleEditField(delta int) {
+// handleNewTrackKey edits the new-track form: printable keys type into
+// the short name and description, Left/Right cycle the type and Enter
+// creates the track.
+func (m Model) handleNewTrackKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := m.CurrentScreen()
+	var text *string
+	switch s.EditFieldIdx {
+	case NewTrackFieldName:
+		text = &m.NewTrack.ShortName
+	case NewTrackFieldDescription:
+		text = &m.NewTrack.Description
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.Stack = m.Stack[:len(m.Stack)-1]
+	case tea.KeyUp, tea.KeyShiftTab:
+		m.MoveEditField(-1)
+	case tea.KeyDown, tea.KeyTab:
+		m.MoveEditField(1)
+	case tea.KeyLeft, tea.KeyRight:
+		if s.EditFieldIdx == NewTrackFieldType {
+			delta := 1
+			if msg.Type == tea.KeyLeft {
+				delta = -1
+			}
+			m.NewTrack.Type = CycleValue(m.Types(), m.NewTrack.Type, delta)
+		}
+	case tea.KeyBackspace, tea.KeyRunes, tea.KeySpace:
+		if text != nil {
+			editText(text, msg)
+		}
+	case tea.KeyEnter:
+		m.NewTrackErr = nil
+		return m, m.CreateTrack()
+	}
+	return m, nil
+}
+
+// handleFieldInputKey edits a text, date, number or list field on
+// ScreenEdit. Ctrl+S, or Enter on a single-line field, saves the value to
+// metadata.json; an invalid value stays in the input with the error shown
+// below it. Esc discards the changes.
+func (m Model) handleFieldInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	sp := &m.Stack[len(m.Stack)-1]
+	f := m.editField()
+	switch {
+	case msg.Type == tea.KeyCtrlS, msg.Type == tea.KeyEnter && !f.Multiline:
+		raw, err := f.Parse(m.FieldInput.String())
+		if err == nil && sp.TrackIdx < len(m.Tracks()) {
+			track := &m.AllTracks[m.resolveTrackIndex(sp.TrackIdx)]
+			if err = track.SetValue(f.Key, raw); err == nil {
+				m.saveCurrentTrack()
+			}
+		}
+		m.FieldErr = err
+		sp.Editing = err != nil
+	case msg.Type == tea.KeyEsc:
+		m.FieldErr = nil
+		sp.Editing = false
+	default:
+		m.FieldInput.Update(msg)
+	}
+	return m, nil
+}
+
+// descWidth is the wrap width of text fields on ScreenEdit.
+func (m Model) descWidth() int {
+	return max(m.Width-m.editLabelWidth()-4, 10)
+}
+
+// editText applies a typing key to a single-line text field: printable
+// keys append, Backspace deletes the last character.
+func editText(text *string, msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyBackspace:
+		if *text != "" {
+			r := []rune(*text)
+			*text = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		*text += string(msg.Runes)
+	}
+}
+
+// revertScreen returns the ScreenRevert for the item under the cursor:
+// the whole track on the tracks list, a phase on the phases list, or a
+// task on the tasks list.
+func (m *Model) revertScreen(s Screen, tracks []conductor.Track) (Screen, bool) {
+	target := Screen{ScreenType: ScreenRevert, TrackIdx: s.TrackIdx, PhaseIdx: -1, TaskIdx: -1}
+	switch s.ScreenType {
+	case ScreenTracks:
+		if s.Cursor >= len(tracks) {
+			return Screen{}, false
+		}
+		target.TrackIdx = s.Cursor
+	case ScreenPhases:
+		if s.TrackIdx >= len(tracks) || s.Cursor >= len(tracks[s.TrackIdx].Phases) {
+			return Screen{}, false
+		}
+		target.PhaseIdx = s.Cursor
+	case ScreenTasks:
+		if s.TrackIdx >= len(tracks) || s.PhaseIdx >= len(tracks[s.TrackIdx].Phases) ||
+			s.Cursor >= len(tracks[s.TrackIdx].Phases[s.PhaseIdx].Tasks) {
+			return Screen{}, false
+		}
+		target.PhaseIdx = s.PhaseIdx
+		target.TaskIdx = s.Cursor
+	default:
+		return Screen{}, false
+	}
+	return target, true
+}
+
+// selectBranch switches the track source to the ref at the given cursor
+// position on the branch screen (0 is the working tree) and returns to
+// the tracks list.
+func (m *Model) selectBranch(cursor int) {
+	m.Branch = ""
+	m.MergeWorktrees = false
+	if cursor > 0 && cursor <= len(m.Branches) {
+		m.Branch = m.Branches[cursor-1]
+	}
+	m.AllTracks = nil
+	m.EventTracks = nil
+	m.Stack = []Screen{{ScreenType: ScreenTracks}}
+}
+
+// cycleEditField cycles the value of the currently selected enum field
+// and reports whether it changed. A field that is not required can be
+// cycled to unset. The status only cycles through the statuses the
+// workflow allows; a backward move asks for confirmation first.
+func (m *Model) cycleEditField(delta int) bool {
 	s := m.CurrentScreen()
 	tracks := m.Tracks()
-	if s.TrackIdx >= len(tracks) {
-		return
+	f := m.editField()
+	if s.TrackIdx >= len(tracks) || f.Kind != conductor.KindEnum {
+		return false
 	}
 	track := &m.AllTracks[m.resolveTrackIndex(s.TrackIdx)]
 
-	switch s.EditFieldIdx {
-	case 0: // Status
-		track.Status = CycleValue(StatusValues, track.Status, delta)
-	case 1: // Type
-		track.Type = CycleValue(TypeValues, track.Type, delta)
+	if f.Key == "status" {
+		next := CycleValue(m.StatusTargets(track.Status), track.Status, delta)
+		if m.IsBackward(track.Status, next) {
+			m.PendingStatus = next
+			m.Stack[len(m.Stack)-1].Confirming = true
+			return false
+		}
+		return m.setStatus(next)
 	}
+
+	options := f.Options
+	if !f.Required {
+		options = append([]string{""}, options...)
+	}
+	raw, _ := f.Parse(CycleValue(options, f.Format(track.Value(f.Key)), delta))
+	_ = track.SetValue(f.Key, raw)
+	return true
+}
+
+// setStatus moves the current track to status following the workflow,
+// updating its lifecycle timestamps, and reports whether it changed.
+func (m *Model) setStatus(status string) bool {
+	s := m.CurrentScreen()
+	if s.TrackIdx >= len(m.Tracks()) {
+		return false
+	}
+	track := &m.AllTracks[m.resolveTrackIndex(s.TrackIdx)]
+	if track.Status == status {
+		return false
+	}
+	return m.Config.Workflow.SetStatus(track, status, time.Now()) == nil
+}
+
+// archiveStatuses lists the statuses offered on ScreenArchive: keeping the
+// current one, then those the workflow allows.
+func (m Model) archiveStatuses() []string {
+	tracks := m.Tracks()
+	s := m.CurrentScreen()
+	if s.TrackIdx >= len(tracks) {
+		return []string{""}
+	}
+	current := tracks[s.TrackIdx].Status
+	return append([]string{""}, slices.DeleteFunc(m.StatusTargets(current), func(v string) bool { return v == current })...)
 }
 
 // saveCurrentTrack persists the current track's metadata to disk.
 func (m *Model) saveCurrentTrack() {
 	s := m.CurrentScreen()
-	path := m.MetadataPath(s.TrackIdx)
-	if path == "" {
-		return
-	}
 	tracks := m.Tracks()
 	if s.TrackIdx >= len(tracks) {
 		return
 	}
 	track := tracks[s.TrackIdx]
 	// Best-effort save; errors are silently ignored in the TUI
-	_ = data.SaveMetadata(path, track)
+	_ = m.Project(track).SaveTrack(track)
 }
 
 // resolveTrackIndex maps a filtered track index to the AllTracks index.
@@ -134,7 +524,7 @@ func (m *Model) resolveTrackIndex(filteredIdx int) int {
 	return 0
 }
 
-func (m *Model) handleEnter(tracks []data.Track) {
+func (m *Model) handleEnter(tracks []conductor.Track) {
 	s := m.CurrentScreen()
 	switch s.ScreenType {
 	case ScreenTracks:
@@ -3,12 +3,25 @@
 package tui
 
 import (
+	"bytes"
+	"context"
+	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/data"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/export"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/git"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/history"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/hooks"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/revert"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/webhook"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
 )
 
 // Version is set at build time via -ldflags.
@@ -21,6 +34,14 @@ const (
 	ScreenTasks
 	ScreenDetail
 	ScreenEdit
+	ScreenHistory
+	ScreenBranches
+	ScreenRevert
+	ScreenExport
+	ScreenLog
+	ScreenNewTrack
+	ScreenArchive
+	ScreenRename
 	ScreenQuit
 )
 
@@ -32,22 +53,232 @@ type Screen struct {
 	TrackIdx     int
 	PhaseIdx     int
 	TaskIdx      int
-	EditFieldIdx int  // index of the currently selected field in edit screen
+	EditFieldIdx int  // index of the currently selected field in the edit and new-track forms
 	Editing      bool // true when actively editing a field value in the edit screen
+	Confirming   bool // true while a confirmation prompt is shown over the screen
 }
 
 // Model is the Bubble Tea model for the Conductor TUI.
 type Model struct {
 	BasePath     string
-	AllTracks    []data.Track
+	AllTracks    []conductor.Track
 	ShowArchived bool
 	Stack        []Screen
 	Width        int
 	Height       int
+
+	// StatusFilter and TypeFilter, when set, restrict the tracks list to
+	// tracks with that status or type.
+	StatusFilter string
+	TypeFilter   string
+	// FilterPin is the track whose screens are open above the list. It
+	// stays in Tracks() when an edit makes it stop matching the filters,
+	// so those screens keep pointing at it.
+	FilterPin string
+
+	// History holds the git-derived metrics for HistoryTrack, shown on
+	// ScreenHistory. HistoryErr is set when the history could not be read.
+	History      history.Report
+	HistoryTrack string
+	HistoryErr   error
+
+	// Branch is the git ref tracks are read from; empty means the working
+	// tree. Branches lists the selectable refs and BranchProgress maps each
+	// track ID to its most advanced branch.
+	Branch           string
+	Branches         []string
+	BranchProgress   map[string]git.Progress
+	BranchesLoadedAt time.Time
+
+	// MergeWorktrees shows the merged live view across all git worktrees,
+	// taking each track from the worktree that modified it most recently.
+	// TrackOrigins records where each merged track came from.
+	MergeWorktrees bool
+	TrackOrigins   map[string]git.TrackOrigin
+
+	// RevertPlan is the dry-run plan shown on ScreenRevert. RevertErr holds
+	// a planning or execution error; RevertDone is set once git revert ran.
+	RevertPlan    revert.Plan
+	RevertLoaded  bool
+	RevertRunning bool
+	RevertDone    bool
+	RevertErr     error
+
+	// ShowBlame overlays git blame attribution on the tasks and detail
+	// screens. Blame holds the lines of the plan.md at BlamePath.
+	ShowBlame bool
+	Blame     map[int]git.BlameLine
+	BlamePath string
+	BlameErr  error
+
+	// ExportAll exports every listed track from ScreenExport instead of
+	// only the one under the cursor. ExportPath and ExportErr report the
+	// outcome of the last export.
+	ExportAll  bool
+	ExportPath string
+	ExportErr  error
+
+	// Config is the project's conductor/tui.json; ConfigErr is set when it
+	// could not be read and the defaults are in use.
+	Config    config.Config
+	ConfigErr error
+
+	// Hooks runs the configured commands for changes detected between
+	// refreshes. EventTracks is the snapshot the next refresh is diffed
+	// against; HookLog holds the latest results, newest first.
+	Hooks        *hooks.Runner
+	EventTracks  []conductor.Track
+	HookLog      []hooks.Result
+	HooksRunning int
+
+	// Webhooks queues detected changes for the configured endpoints and
+	// is flushed on every refresh. WebhookResult is the outcome of the
+	// last flush; WebhookErr is set when events could not be queued.
+	Webhooks      *webhook.Outbox
+	WebhookResult webhook.Result
+	WebhookErr    error
+
+	// NewTrack holds the form on ScreenNewTrack. NewTrackErr is set when
+	// the track could not be created and the form stays open.
+	NewTrack    NewTrackForm
+	NewTrackErr error
+
+	// ArchiveFiles lists the files ScreenArchive will move, relative to
+	// the track directory. ArchiveStatus is the status to set on the way,
+	// empty to keep it; ArchiveErr reports a failed listing or move.
+	ArchiveFiles  []string
+	ArchiveStatus string
+	ArchiveErr    error
+
+	// RenameID is the new track ID typed on ScreenRename; RenameErr is set
+	// when the rename failed and was rolled back.
+	RenameID  string
+	RenameErr error
+
+	// PendingStatus is the status a backward move on ScreenEdit goes to
+	// once it is confirmed.
+	PendingStatus string
+
+	// FieldInput is the text, date, number or list field being edited on
+	// ScreenEdit. It is written to metadata.json only when the edit is
+	// confirmed and the value is valid; FieldErr says why it was not.
+	FieldInput TextArea
+	FieldErr   error
+
+	// EditorErr is set when the external editor could not be run or
+	// exited with an error; it is shown above the footer.
+	EditorErr error
+
+	// PlanInput is the name typed for a new or renamed phase, task or
+	// sub-task on ScreenPhases, ScreenTasks and ScreenDetail, and
+	// PlanAction the edit it is for. PlanErr reports a failed plan edit
+	// or undo. PlanUndo holds this session's plan edits, oldest first.
+	PlanInput  string
+	PlanAction int
+	PlanErr    error
+	PlanUndo   []PlanChange
 }
 
-// TracksLoadedMsg carries newly loaded tracks.
-type TracksLoadedMsg []data.Track
+// NewTrackForm is the input of the new-track form.
+type NewTrackForm struct {
+	ShortName   string
+	Type        string
+	Description string
+}
+
+// New-track form fields, in display order.
+const (
+	NewTrackFieldName = iota
+	NewTrackFieldType
+	NewTrackFieldDescription
+	NewTrackFieldCount
+)
+
+// TracksLoadedMsg carries newly loaded tracks and the source they were
+// loaded for: Branch, or the working tree when empty, and whether the
+// merged worktree view was on. A message for a source the model has since
+// switched away from is dropped.
+type TracksLoadedMsg struct {
+	Tracks         []conductor.Track
+	Branch         string
+	MergeWorktrees bool
+}
+
+// HistoryLoadedMsg carries the git history report for a track.
+type HistoryLoadedMsg struct {
+	TrackID string
+	Report  history.Report
+	Err     error
+}
+
+// WorktreeTracksLoadedMsg carries the merged tracks from all worktrees
+// and the worktree each track was taken from.
+type WorktreeTracksLoadedMsg struct {
+	Tracks  []conductor.Track
+	Origins map[string]git.TrackOrigin
+}
+
+// RevertPlanMsg carries a freshly built revert plan.
+type RevertPlanMsg struct {
+	Plan revert.Plan
+	Err  error
+}
+
+// RevertDoneMsg reports the outcome of executing a revert plan.
+type RevertDoneMsg struct {
+	Err error
+}
+
+// BlameLoadedMsg carries git blame output for a plan.md file.
+type BlameLoadedMsg struct {
+	Path  string
+	Lines map[int]git.BlameLine
+	Err   error
+}
+
+// HookResultMsg carries the outcome of a hook command.
+type HookResultMsg hooks.Result
+
+// WebhookFlushedMsg carries the outcome of a webhook outbox flush.
+type WebhookFlushedMsg webhook.Result
+
+// hookLogSize caps the number of hook results kept for the log panel.
+const hookLogSize = 100
+
+// ExportDoneMsg reports the file written by an export.
+type ExportDoneMsg struct {
+	Path string
+	Err  error
+}
+
+// TrackCreatedMsg reports the outcome of creating a track.
+type TrackCreatedMsg struct {
+	Track conductor.Track
+	Err   error
+}
+
+// TrackMovedMsg reports the outcome of archiving or unarchiving a track.
+type TrackMovedMsg struct {
+	Track conductor.Track
+	Err   error
+}
+
+// TrackRenamedMsg reports the outcome of renaming a track.
+type TrackRenamedMsg struct {
+	Track conductor.Track
+	Err   error
+}
+
+// BranchesLoadedMsg carries the refs available for branch selection and
+// the per-track progress on each track's most advanced branch.
+type BranchesLoadedMsg struct {
+	Refs     []string
+	Progress map[string]git.Progress
+}
+
+// branchRefreshInterval is how often branch progress is recomputed.
+// Reading every ref is much more expensive than a working-tree scan.
+const branchRefreshInterval = 30 * time.Second
 
 // tickMsg triggers a data refresh.
 type tickMsg time.Time
@@ -60,11 +291,16 @@ func tickCmd() tea.Cmd {
 
 // NewModel creates a new Model with default settings.
 func NewModel(basePath string) Model {
+	cfg, err := config.Load(basePath)
 	return Model{
-		BasePath: basePath,
-		Stack:    []Screen{{ScreenType: ScreenTracks}},
-		Width:    80,
-		Height:   24,
+		BasePath:  basePath,
+		Stack:     []Screen{{ScreenType: ScreenTracks}},
+		Width:     80,
+		Height:    24,
+		Config:    cfg,
+		ConfigErr: err,
+		Hooks:     hooks.NewRunner(basePath, cfg.Hooks),
+		Webhooks:  webhook.New(basePath, cfg.Webhooks),
 	}
 }
 
@@ -73,29 +309,87 @@ func (m Model) CurrentScreen() Screen {
 	return m.Stack[len(m.Stack)-1]
 }
 
-// Tracks returns the list of tracks filtered by archive visibility.
-func (m Model) Tracks() []data.Track {
-	if m.ShowArchived {
+// Tracks returns the list of tracks filtered by archive visibility and
+// the status and type filters.
+func (m Model) Tracks() []conductor.Track {
+	f := conductor.Filter{Archived: m.ShowArchived}
+	if m.StatusFilter != "" {
+		f.Statuses = []string{m.StatusFilter}
+	}
+	if m.TypeFilter != "" {
+		f.Types = []string{m.TypeFilter}
+	}
+	if f.Archived && f.Statuses == nil && f.Types == nil {
 		return m.AllTracks
 	}
-	var filtered []data.Track
+	var out []conductor.Track
 	for _, t := range m.AllTracks {
-		if t.Source != "archived" {
-			filtered = append(filtered, t)
+		if f.Match(t) || t.TrackID == m.FilterPin && (f.Archived || t.Source != "archived") {
+			out = append(out, t)
 		}
 	}
-	return filtered
+	return out
+}
+
+// Statuses returns the track statuses offered for selection, in display
+// order: the project's configured ones, or StatusValues.
+func (m Model) Statuses() []string {
+	if len(m.Config.Statuses) == 0 {
+		return StatusValues
+	}
+	return m.Config.Statuses.Values()
+}
+
+// Types returns the track types offered for selection, in display order:
+// the project's configured ones, or TypeValues.
+func (m Model) Types() []string {
+	if len(m.Config.Types) == 0 {
+		return TypeValues
+	}
+	return m.Config.Types.Values()
 }
 
 // Init starts the first data load and the tick timer.
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.LoadTracks(), tickCmd())
+	return tea.Batch(m.LoadTracks(), m.LoadBranches(), tickCmd())
 }
 
-// LoadTracks returns a command that discovers tracks from the filesystem.
+// LoadTracks returns a command that discovers tracks from the filesystem,
+// from the selected git ref when a branch is selected, or from all
+// worktrees in the merged worktree view.
 func (m Model) LoadTracks() tea.Cmd {
+	basePath, branch, merge := m.BasePath, m.Branch, m.MergeWorktrees
 	return func() tea.Msg {
-		return TracksLoadedMsg(data.DiscoverTracks(m.BasePath))
+		if merge {
+			tracks, origins, err := git.MergeWorktrees(basePath)
+			if err != nil {
+				return TracksLoadedMsg{Tracks: conductor.DiscoverTracks(basePath), MergeWorktrees: true}
+			}
+			return WorktreeTracksLoadedMsg{Tracks: tracks, Origins: origins}
+		}
+		if branch == "" {
+			return TracksLoadedMsg{Tracks: conductor.DiscoverTracks(basePath)}
+		}
+		tracks, err := git.DiscoverTracks(basePath, branch)
+		if err != nil {
+			return nil
+		}
+		return TracksLoadedMsg{Tracks: tracks, Branch: branch}
+	}
+}
+
+// LoadBranches returns a command that lists git refs and computes each
+// track's progress on its most advanced branch. Outside a git repository
+// it yields an empty result.
+func (m Model) LoadBranches() tea.Cmd {
+	basePath := m.BasePath
+	return func() tea.Msg {
+		refs, err := git.Refs(basePath)
+		if err != nil {
+			return BranchesLoadedMsg{}
+		}
+		progress, _ := git.BranchProgress(basePath)
+		return BranchesLoadedMsg{Refs: refs, Progress: progress}
 	}
 }
 
@@ -108,11 +402,121 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case TracksLoadedMsg:
-		m.AllTracks = []data.Track(msg)
+		if msg.Branch != m.Branch || msg.MergeWorktrees != m.MergeWorktrees {
+			return m, nil // loaded before a branch or worktree switch
+		}
+		m.AllTracks = msg.Tracks
+		m.TrackOrigins = nil
+		if m.Branch != "" {
+			return m, nil
+		}
+		return m, m.detectChanges(m.AllTracks)
+
+	case WorktreeTracksLoadedMsg:
+		if !m.MergeWorktrees {
+			return m, nil
+		}
+		m.AllTracks = msg.Tracks
+		m.TrackOrigins = msg.Origins
+		return m, m.detectChanges(m.AllTracks)
+
+	case HookResultMsg:
+		m.HooksRunning--
+		m.HookLog = append([]hooks.Result{hooks.Result(msg)}, m.HookLog...)
+		if len(m.HookLog) > hookLogSize {
+			m.HookLog = m.HookLog[:hookLogSize]
+		}
+		return m, nil
+
+	case WebhookFlushedMsg:
+		m.WebhookResult = webhook.Result(msg)
+		return m, nil
+
+	case HistoryLoadedMsg:
+		s, tracks := m.CurrentScreen(), m.Tracks()
+		if s.ScreenType != ScreenHistory || s.TrackIdx >= len(tracks) || tracks[s.TrackIdx].TrackID != msg.TrackID {
+			return m, nil // for a history screen that has since been closed
+		}
+		m.HistoryTrack = msg.TrackID
+		m.History = msg.Report
+		m.HistoryErr = msg.Err
+		return m, nil
+
+	case RevertPlanMsg:
+		m.RevertPlan = msg.Plan
+		m.RevertErr = msg.Err
+		m.RevertLoaded = true
+		return m, nil
+
+	case RevertDoneMsg:
+		m.RevertRunning = false
+		m.RevertDone = msg.Err == nil
+		m.RevertErr = msg.Err
+		return m, m.LoadTracks()
+
+	case BlameLoadedMsg:
+		m.BlamePath = msg.Path
+		m.Blame = msg.Lines
+		m.BlameErr = msg.Err
+		return m, nil
+
+	case ExportDoneMsg:
+		m.ExportPath = msg.Path
+		m.ExportErr = msg.Err
+		return m, nil
+
+	case TrackCreatedMsg:
+		if msg.Err != nil {
+			m.NewTrackErr = msg.Err
+			return m, nil
+		}
+		// The new track is the newest, so it sorts to the top.
+		m.Stack = []Screen{{ScreenType: ScreenTracks}}
+		return m, m.LoadTracks()
+
+	case TrackMovedMsg:
+		if msg.Err != nil {
+			m.ArchiveErr = msg.Err
+			return m, nil
+		}
+		m.Stack = []Screen{{ScreenType: ScreenTracks}}
+		return m, m.LoadTracks()
+
+	case TrackRenamedMsg:
+		if msg.Err != nil {
+			m.RenameErr = msg.Err
+			return m, nil
+		}
+		m.Stack = m.Stack[:len(m.Stack)-1]
+		return m, m.LoadTracks()
+
+	case EditorClosedMsg:
+		m.EditorErr = msg.Err
+		return m, m.ReloadTrack(msg.Track)
+
+	case TrackReloadedMsg:
+		i := slices.IndexFunc(m.AllTracks, func(t conductor.Track) bool { return t.TrackID == msg.Track.TrackID })
+		if msg.Err != nil || i < 0 {
+			// Renamed or broken: fall back to a full reload.
+			return m, m.LoadTracks()
+		}
+		m.AllTracks = slices.Clone(m.AllTracks)
+		m.AllTracks[i] = msg.Track
+		return m, m.detectChanges(m.AllTracks)
+
+	case BranchesLoadedMsg:
+		m.Branches = msg.Refs
+		m.BranchProgress = msg.Progress
+		m.BranchesLoadedAt = time.Now()
 		return m, nil
 
 	case tickMsg:
-		return m, tea.Batch(m.LoadTracks(), tickCmd())
+		if time.Since(m.BranchesLoadedAt) >= branchRefreshInterval {
+			// Push the timestamp forward so slow loads are not re-queued.
+			m.BranchesLoadedAt = time.Now()
+			return m, tea.Batch(m.LoadTracks(), m.LoadBranches(), m.refreshBlame(), m.flushWebhooks(), tickCmd())
+		}
+		return m, tea.Batch(m.LoadTracks(), m.refreshBlame(), m.flushWebhooks(), tickCmd())
 
 	case tea.KeyMsg:
 		return m.HandleKey(msg)
@@ -120,14 +524,51 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// EditFieldCount is the number of editable fields on the edit screen.
-const EditFieldCount = 2
+// Built-in edit screen fields, in display order. The fields configured
+// in conductor/tui.json follow them.
+const (
+	EditFieldStatus = iota
+	EditFieldType
+	EditFieldDescription
+)
+
+// EditFields returns the fields of the edit screen: status, type and
+// description, then the project's configured fields.
+func (m Model) EditFields() []conductor.Field {
+	fields := []conductor.Field{
+		{Key: "status", Label: "Status", Kind: conductor.KindEnum, Options: m.Statuses(), Required: true},
+		{Key: "type", Label: "Type", Kind: conductor.KindEnum, Options: m.Types(), Required: true},
+		{Key: "description", Label: "Description", Kind: conductor.KindText, Required: true, Multiline: true},
+	}
+	return append(fields, m.Config.Fields...)
+}
+
+// editField returns the field selected on the edit screen.
+func (m Model) editField() conductor.Field {
+	fields := m.EditFields()
+	return fields[min(m.CurrentScreen().EditFieldIdx, len(fields)-1)]
+}
+
+// StatusValues defines the cycle order for the Status field when the
+// project configures no statuses.
+var StatusValues = conductor.StatusValues
 
-// StatusValues defines the cycle order for the Status field.
-var StatusValues = []string{"new", "in_progress", "completed", "cancelled"}
+// TypeValues defines the cycle order for the Type field when the project
+// configures no types.
+var TypeValues = conductor.TypeValues
 
-// TypeValues defines the cycle order for the Type field.
-var TypeValues = []string{"feature", "bug", "chore", "refactor"}
+// ExportOptions lists the report types offered on ScreenExport.
+var ExportOptions = []struct {
+	Label  string
+	Format string
+	Level  string
+}{
+	{"Markdown summary", "markdown", ""},
+	{"HTML report", "html", ""},
+	{"CSV (one row per track)", "csv", "track"},
+	{"CSV (one row per phase)", "csv", "phase"},
+	{"CSV (one row per task)", "csv", "task"},
+}
 
 // CycleValue returns the next value in the cycle, moving by delta steps.
 // If the current value is not found, returns the first value in the list.
@@ -165,7 +606,21 @@ func (m Model) ItemCount() int {
 			return len(tracks[s.TrackIdx].Phases[s.PhaseIdx].Tasks[s.TaskIdx].SubTasks)
 		}
 	case ScreenEdit:
-		return EditFieldCount
+		return len(m.EditFields())
+	case ScreenHistory:
+		return len(m.History.Cycles)
+	case ScreenBranches:
+		return len(m.Branches) + 1 // working tree + refs
+	case ScreenRevert:
+		return len(m.RevertPlan.Commits)
+	case ScreenExport:
+		return len(ExportOptions)
+	case ScreenLog:
+		return len(m.HookLog)
+	case ScreenNewTrack:
+		return NewTrackFieldCount
+	case ScreenArchive:
+		return len(m.ArchiveFiles)
 	}
 	return 0
 }
@@ -187,20 +642,72 @@ func (m *Model) MoveCursor(delta int) {
 	s.Cursor = next
 }
 
-// MetadataPath returns the filesystem path to the metadata.json file for
-// the track at the given filtered index.
-func (m Model) MetadataPath(filteredIdx int) string {
+// TrackDir returns the filesystem path to the directory of the track at
+// the given filtered index. In the merged worktree view this is the
+// directory in the worktree the track was taken from.
+func (m Model) TrackDir(filteredIdx int) string {
 	tracks := m.Tracks()
 	if filteredIdx >= len(tracks) {
 		return ""
 	}
 	track := tracks[filteredIdx]
+	return m.Project(track).Dir(track)
+}
+
+// Project returns the project a track was loaded from: the worktree it
+// came from in the merged worktree view, BasePath otherwise.
+func (m Model) Project(t conductor.Track) conductor.Project {
+	p := conductor.New(m.BasePath)
+	if origin, ok := m.TrackOrigins[t.TrackID]; ok {
+		p = conductor.New(origin.BasePath)
+	}
+	p.Workflow = m.Config.Workflow
+	return p
+}
+
+// StatusTargets returns the statuses a track with status from may move to,
+// including from itself, in display order.
+func (m Model) StatusTargets(from string) []string {
+	var targets []string
+	for _, s := range m.Statuses() {
+		if m.Config.Workflow.Allows(from, s) {
+			targets = append(targets, s)
+		}
+	}
+	return targets
+}
 
-	dir := "tracks"
-	if track.Source == "archived" {
-		dir = "archive"
+// IsBackward reports whether moving from one status to another goes back
+// in the display order of the statuses.
+func (m Model) IsBackward(from, to string) bool {
+	statuses := m.Statuses()
+	i, j := slices.Index(statuses, from), slices.Index(statuses, to)
+	return i >= 0 && j >= 0 && j < i
+}
+
+// MetadataPath returns the filesystem path to the metadata.json file for
+// the track at the given filtered index.
+func (m Model) MetadataPath(filteredIdx int) string {
+	dir := m.TrackDir(filteredIdx)
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "metadata.json")
+}
+
+// LoadHistory returns a command that reads the plan.md git history of the
+// track at the given filtered index.
+func (m Model) LoadHistory(filteredIdx int) tea.Cmd {
+	tracks := m.Tracks()
+	dir := m.TrackDir(filteredIdx)
+	if dir == "" {
+		return nil
+	}
+	trackID := tracks[filteredIdx].TrackID
+	return func() tea.Msg {
+		report, err := history.Analyze(filepath.Join(dir, "plan.md"))
+		return HistoryLoadedMsg{TrackID: trackID, Report: report, Err: err}
 	}
-	return filepath.Join(m.BasePath, "conductor", dir, track.TrackID, "metadata.json")
 }
 
 // MoveEditField moves the edit field index by delta, clamping to valid range.
@@ -210,8 +717,8 @@ func (m *Model) MoveEditField(delta int) {
 	if next < 0 {
 		next = 0
 	}
-	if next >= EditFieldCount {
-		next = EditFieldCount - 1
+	if count := m.ItemCount(); next >= count {
+		next = count - 1
 	}
 	s.EditFieldIdx = next
 }
@@ -225,3 +732,221 @@ func (m *Model) MoveScroll(delta int) {
 	}
 	s.Scroll = next
 }
+
+// revertTarget builds the revert target for a ScreenRevert screen.
+func (m Model) revertTarget(s Screen) (revert.Target, bool) {
+	tracks := m.Tracks()
+	if s.TrackIdx >= len(tracks) {
+		return revert.Target{}, false
+	}
+	return revert.Target{
+		Track:    tracks[s.TrackIdx],
+		Dir:      m.TrackDir(s.TrackIdx),
+		PhaseIdx: s.PhaseIdx,
+		TaskIdx:  s.TaskIdx,
+	}, true
+}
+
+// BuildRevertPlan returns a command that builds the revert plan for the
+// current ScreenRevert target.
+func (m Model) BuildRevertPlan() tea.Cmd {
+	target, ok := m.revertTarget(m.CurrentScreen())
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		plan, err := revert.Build(target)
+		return RevertPlanMsg{Plan: plan, Err: err}
+	}
+}
+
+// ExecuteRevert returns a command that runs git revert for the loaded plan.
+func (m Model) ExecuteRevert() tea.Cmd {
+	target, ok := m.revertTarget(m.CurrentScreen())
+	if !ok {
+		return nil
+	}
+	plan := m.RevertPlan
+	return func() tea.Msg {
+		return RevertDoneMsg{Err: revert.Execute(target.Dir, plan)}
+	}
+}
+
+// PlanPath returns the filesystem path to the plan.md file for the track
+// at the given filtered index.
+func (m Model) PlanPath(filteredIdx int) string {
+	dir := m.TrackDir(filteredIdx)
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "plan.md")
+}
+
+// LoadBlame returns a command that runs git blame on the plan.md of the
+// track at the given filtered index.
+func (m Model) LoadBlame(filteredIdx int) tea.Cmd {
+	path := m.PlanPath(filteredIdx)
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		lines, err := git.Blame(path)
+		return BlameLoadedMsg{Path: path, Lines: lines, Err: err}
+	}
+}
+
+// refreshBlame reloads blame data while the overlay is visible, so that
+// checkbox changes picked up by the periodic refresh stay attributed.
+func (m Model) refreshBlame() tea.Cmd {
+	s := m.CurrentScreen()
+	if !m.ShowBlame || (s.ScreenType != ScreenTasks && s.ScreenType != ScreenDetail) {
+		return nil
+	}
+	return m.LoadBlame(s.TrackIdx)
+}
+
+// BlameFor returns the blame attribution for a plan.md line of the
+// current screen's track, if loaded.
+func (m Model) BlameFor(line int) (git.BlameLine, bool) {
+	if m.BlamePath == "" || m.BlamePath != m.PlanPath(m.CurrentScreen().TrackIdx) {
+		return git.BlameLine{}, false
+	}
+	b, ok := m.Blame[line]
+	return b, ok
+}
+
+// detectChanges diffs tracks against the previous snapshot, queues the
+// resulting events for the configured webhooks and returns a command running
+// every hook that matches them. The first load after startup or a view
+// switch only records the snapshot.
+func (m *Model) detectChanges(tracks []conductor.Track) tea.Cmd {
+	prev := m.EventTracks
+	m.EventTracks = append([]conductor.Track(nil), tracks...)
+	hooksOn := m.Hooks != nil && len(m.Hooks.Commands) > 0
+	webhooksOn := m.Webhooks != nil && m.Webhooks.Enabled()
+	if prev == nil || (!hooksOn && !webhooksOn) {
+		return nil
+	}
+	evs := events.Diff(prev, tracks, time.Now().UTC())
+	if len(evs) == 0 {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if webhooksOn {
+		m.WebhookErr = m.Webhooks.Enqueue(evs)
+		cmds = append(cmds, m.flushWebhooks())
+	}
+	if hooksOn {
+		jobs := m.Hooks.Jobs(evs)
+		m.HooksRunning += len(jobs)
+		runner := m.Hooks
+		for _, job := range jobs {
+			cmds = append(cmds, func() tea.Msg {
+				return HookResultMsg(runner.Run(context.Background(), job))
+			})
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// flushWebhooks returns a command delivering the due webhook deliveries,
+// or nil when no endpoint is configured. A flush that overlaps a running
+// one reports nothing.
+func (m Model) flushWebhooks() tea.Cmd {
+	if m.Webhooks == nil || !m.Webhooks.Enabled() {
+		return nil
+	}
+	outbox := m.Webhooks
+	return func() tea.Msg {
+		res, ok := outbox.Flush(context.Background())
+		if !ok {
+			return nil
+		}
+		return WebhookFlushedMsg(res)
+	}
+}
+
+// Export returns a command that writes the report selected on ScreenExport
+// to conductor-report-<date>[-<level>].<ext> in the project root, using the
+// project's template override when one exists.
+func (m Model) Export(s Screen) tea.Cmd {
+	tracks := m.Tracks()
+	if !m.ExportAll {
+		if s.TrackIdx >= len(tracks) {
+			return nil
+		}
+		tracks = tracks[s.TrackIdx : s.TrackIdx+1]
+	}
+	opt := ExportOptions[s.Cursor]
+	basePath := m.BasePath
+	return func() tea.Msg {
+		now := time.Now()
+		opts := export.Options{Format: opt.Format, Level: opt.Level}
+		if p := export.TemplatePath(basePath, opt.Format); opt.Format != "csv" {
+			if _, err := os.Stat(p); err == nil {
+				opts.Template = p
+			}
+		}
+		name := "conductor-report-" + now.Format("20060102")
+		if opt.Level != "" {
+			name += "-" + opt.Level
+		}
+		path := filepath.Join(basePath, name+export.Extension(opt.Format))
+
+		var buf bytes.Buffer
+		if err := export.Write(&buf, export.Build(tracks, now), opts); err != nil {
+			return ExportDoneMsg{Err: err}
+		}
+		if err := conductor.WriteFileAtomic(path, buf.Bytes()); err != nil {
+			return ExportDoneMsg{Err: err}
+		}
+		return ExportDoneMsg{Path: path}
+	}
+}
+
+// CreateTrack returns a command that scaffolds the track described by the
+// new-track form in the working tree.
+func (m Model) CreateTrack() tea.Cmd {
+	p, form := conductor.New(m.BasePath), m.NewTrack
+	return func() tea.Msg {
+		t, err := p.CreateTrack(strings.TrimSpace(form.ShortName), form.Type, form.Description, time.Now())
+		return TrackCreatedMsg{Track: t, Err: err}
+	}
+}
+
+// MoveTrack returns a command that archives the active track at the given
+// filtered index, or unarchives it when it is archived, setting
+// ArchiveStatus on the way.
+func (m Model) MoveTrack(filteredIdx int) tea.Cmd {
+	tracks := m.Tracks()
+	if filteredIdx >= len(tracks) {
+		return nil
+	}
+	t, status := tracks[filteredIdx], m.ArchiveStatus
+	p := m.Project(t)
+	return func() tea.Msg {
+		var err error
+		if t.Source == "archived" {
+			t, err = p.Unarchive(t, status)
+		} else {
+			t, err = p.Archive(t, status)
+		}
+		return TrackMovedMsg{Track: t, Err: err}
+	}
+}
+
+// RenameTrack returns a command that renames the track at the given
+// filtered index to RenameID.
+func (m Model) RenameTrack(filteredIdx int) tea.Cmd {
+	tracks := m.Tracks()
+	if filteredIdx >= len(tracks) {
+		return nil
+	}
+	t, id := tracks[filteredIdx], strings.TrimSpace(m.RenameID)
+	p := m.Project(t)
+	return func() tea.Msg {
+		t, err := p.RenameTrack(t, id)
+		return TrackRenamedMsg{Track: t, Err: err}
+	}
+}
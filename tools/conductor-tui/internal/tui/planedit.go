@@ -0,0 +1,277 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
+)
+
+// Plan edits started from ScreenPhases, ScreenTasks and ScreenDetail that
+// ask for a name first.
+const (
+	PlanAddPhase = iota + 1
+	PlanAddTask
+	PlanAddSubTask
+	PlanRename
+)
+
+// PlanChange is a plan.md edit made in this session, kept so that it can
+// be undone.
+type PlanChange struct {
+	Path   string
+	Before string
+	After  string
+}
+
+// isPlanScreen reports whether screens of type st edit the plan.
+func isPlanScreen(st int) bool {
+	return st == ScreenPhases || st == ScreenTasks || st == ScreenDetail
+}
+
+// handlePlanKey handles the plan editing keys on ScreenPhases, ScreenTasks
+// and ScreenDetail, and reports whether msg was one of them:
+//
+//	n  new phase, task or sub-task below the cursor
+//	a  add a task to the phase, or a sub-task to the task, under the cursor
+//	e  rename the item under the cursor
+//	d  delete the item under the cursor, after confirmation
+//	K/J  move the task up or down
+//	</>  move the task to the end of the previous or next phase
+//	u  undo the last plan edit of the track
+func (m Model) handlePlanKey(msg tea.KeyMsg) (Model, bool) {
+	s := m.CurrentScreen()
+	sp := &m.Stack[len(m.Stack)-1]
+	name, line := m.selectedPlanItem()
+	switch key := msg.String(); key {
+	case "n":
+		action := map[int]int{ScreenPhases: PlanAddPhase, ScreenTasks: PlanAddTask, ScreenDetail: PlanAddSubTask}[s.ScreenType]
+		m.startPlanInput(action, "")
+	case "a":
+		switch {
+		case s.ScreenType == ScreenPhases && line > 0:
+			m.startPlanInput(PlanAddTask, "")
+		case s.ScreenType == ScreenTasks && line > 0:
+			m.startPlanInput(PlanAddSubTask, "")
+		}
+	case "e":
+		if line > 0 {
+			m.startPlanInput(PlanRename, name)
+		}
+	case "d":
+		if line > 0 && (s.ScreenType != ScreenDetail || s.Cursor < m.ItemCount()) {
+			m.PlanErr = nil
+			sp.Confirming = true
+		}
+	case "K", "J":
+		delta := 1
+		if key == "K" {
+			delta = -1
+		}
+		if s.ScreenType == ScreenTasks && line > 0 &&
+			m.editPlan(func(c string) (string, error) { return conductor.MoveTask(c, line, delta) }) {
+			m.MoveCursor(delta)
+		}
+	case "<", ">":
+		to := s.PhaseIdx + 1
+		if key == "<" {
+			to = s.PhaseIdx - 1
+		}
+		if s.ScreenType != ScreenTasks || line == 0 || to < 0 || to >= len(m.Tracks()[s.TrackIdx].Phases) {
+			break
+		}
+		heading := m.Tracks()[s.TrackIdx].Phases[to].Line
+		if m.editPlan(func(c string) (string, error) { return conductor.MoveTaskToPhase(c, line, heading) }) {
+			// Follow the task to the end of its new phase.
+			sp.PhaseIdx = to
+			sp.Cursor = len(m.Tracks()[s.TrackIdx].Phases[to].Tasks) - 1
+			sp.Scroll = 0
+		}
+	case "u":
+		m.undoPlanEdit()
+	default:
+		return m, false
+	}
+	return m, true
+}
+
+// selectedPlanItem returns the name and plan.md line of the phase, task or
+// sub-task under the cursor. On ScreenDetail that is the selected
+// sub-task, or the task itself when it has none. The line is 0 when
+// nothing is selected.
+func (m Model) selectedPlanItem() (string, int) {
+	s, tracks := m.CurrentScreen(), m.Tracks()
+	if s.TrackIdx >= len(tracks) {
+		return "", 0
+	}
+	phases := tracks[s.TrackIdx].Phases
+	switch s.ScreenType {
+	case ScreenPhases:
+		if s.Cursor < len(phases) {
+			return phases[s.Cursor].Name, phases[s.Cursor].Line
+		}
+	case ScreenTasks:
+		if s.PhaseIdx < len(phases) && s.Cursor < len(phases[s.PhaseIdx].Tasks) {
+			t := phases[s.PhaseIdx].Tasks[s.Cursor]
+			return t.Name, t.Line
+		}
+	case ScreenDetail:
+		if s.PhaseIdx < len(phases) && s.TaskIdx < len(phases[s.PhaseIdx].Tasks) {
+			t := phases[s.PhaseIdx].Tasks[s.TaskIdx]
+			if s.Cursor < len(t.SubTasks) {
+				return t.SubTasks[s.Cursor].Name, t.SubTasks[s.Cursor].Line
+			}
+			return t.Name, t.Line
+		}
+	}
+	return "", 0
+}
+
+// startPlanInput opens the name input for action over the current screen.
+func (m *Model) startPlanInput(action int, name string) {
+	m.PlanAction = action
+	m.PlanInput = name
+	m.PlanErr = nil
+	m.Stack[len(m.Stack)-1].Editing = true
+}
+
+// handlePlanInputKey edits the name of a new or renamed plan item. Enter
+// writes it to plan.md; an invalid name keeps the input open with the
+// error shown below it. Esc cancels.
+func (m Model) handlePlanInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	sp := &m.Stack[len(m.Stack)-1]
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.PlanErr = nil
+		sp.Editing = false
+	case tea.KeyEnter:
+		if m.submitPlanInput() {
+			sp.Editing = false
+		}
+	default:
+		editText(&m.PlanInput, msg)
+	}
+	return m, nil
+}
+
+// submitPlanInput applies the pending add or rename and moves the cursor
+// to the new item. It reports whether the edit was written.
+func (m *Model) submitPlanInput() bool {
+	s := m.CurrentScreen()
+	name, line := m.selectedPlanItem()
+	input := m.PlanInput
+	var edit func(string) (string, error)
+	cursor := s.Cursor + 1
+	switch m.PlanAction {
+	case PlanAddPhase:
+		edit = func(c string) (string, error) { return conductor.InsertPhase(c, line, input) }
+	case PlanAddTask:
+		switch {
+		case s.ScreenType == ScreenPhases:
+			// At the end of the phase under the cursor.
+			phase := m.Tracks()[s.TrackIdx].Phases[s.Cursor]
+			if n := len(phase.Tasks); n > 0 {
+				line = phase.Tasks[n-1].Line
+			}
+			cursor = s.Cursor
+		case line == 0:
+			// The first task of an empty phase.
+			line = m.Tracks()[s.TrackIdx].Phases[s.PhaseIdx].Line
+		}
+		edit = func(c string) (string, error) { return conductor.InsertTask(c, line, input) }
+	case PlanAddSubTask:
+		if s.ScreenType == ScreenTasks {
+			cursor = s.Cursor
+		}
+		edit = func(c string) (string, error) { return conductor.InsertSubTask(c, line, input) }
+	case PlanRename:
+		if input == name {
+			return true
+		}
+		edit = func(c string) (string, error) { return conductor.RenamePlanItem(c, line, input) }
+		cursor = s.Cursor
+	}
+	if edit == nil || !m.editPlan(edit) {
+		return m.PlanErr == nil
+	}
+	m.MoveCursor(cursor - s.Cursor)
+	return true
+}
+
+// deletePlanItem removes the item under the cursor from plan.md.
+func (m *Model) deletePlanItem() {
+	_, line := m.selectedPlanItem()
+	if line > 0 && m.editPlan(func(c string) (string, error) { return conductor.DeletePlanItem(c, line) }) {
+		m.MoveCursor(0)
+	}
+}
+
+// editPlan applies edit to the plan.md of the current track, records it
+// for undo and updates the loaded track. It reports whether the plan
+// changed; PlanErr says why it did not.
+func (m *Model) editPlan(edit func(string) (string, error)) bool {
+	s := m.CurrentScreen()
+	path := m.PlanPath(s.TrackIdx)
+	if path == "" {
+		return false
+	}
+	before, after, err := conductor.EditPlan(path, edit)
+	m.PlanErr = err
+	if err != nil || before == after {
+		return false
+	}
+	m.PlanUndo = append(m.PlanUndo, PlanChange{Path: path, Before: before, After: after})
+	m.AllTracks[m.resolveTrackIndex(s.TrackIdx)].Phases = conductor.ParsePlan(after)
+	return true
+}
+
+// undoPlanEdit reverts the last plan edit of this session to the current
+// track's plan.md. An edit is only undone while the file still holds what
+// it wrote.
+func (m *Model) undoPlanEdit() {
+	s := m.CurrentScreen()
+	path := m.PlanPath(s.TrackIdx)
+	i := len(m.PlanUndo) - 1
+	for i >= 0 && m.PlanUndo[i].Path != path {
+		i--
+	}
+	if path == "" || i < 0 {
+		m.PlanErr = errors.New("nothing to undo")
+		return
+	}
+	change := m.PlanUndo[i]
+	m.PlanUndo = slices.Delete(m.PlanUndo, i, i+1)
+	raw, err := os.ReadFile(path)
+	if err == nil && string(raw) != change.After {
+		err = errors.New("plan.md has changed since the edit, so it cannot be undone")
+	}
+	if err == nil {
+		err = conductor.WriteFileAtomic(path, []byte(change.Before))
+	}
+	m.PlanErr = err
+	if err != nil {
+		return
+	}
+	m.AllTracks[m.resolveTrackIndex(s.TrackIdx)].Phases = conductor.ParsePlan(change.Before)
+	m.dropStaleScreens()
+	m.MoveCursor(0)
+}
+
+// dropStaleScreens closes the tasks and detail screens of phases and
+// tasks that an undo removed.
+func (m *Model) dropStaleScreens() {
+	for len(m.Stack) > 1 {
+		s := m.CurrentScreen()
+		phases := m.Tracks()[s.TrackIdx].Phases
+		switch {
+		case s.ScreenType == ScreenTasks && s.PhaseIdx >= len(phases),
+			s.ScreenType == ScreenDetail && (s.PhaseIdx >= len(phases) || s.TaskIdx >= len(phases[s.PhaseIdx].Tasks)):
+			m.Stack = m.Stack[:len(m.Stack)-1]
+		default:
+			return
+		}
+	}
+}
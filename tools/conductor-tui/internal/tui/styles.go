@@ -11,9 +11,12 @@ var (
 	DimStyle    = lipgloss.NewStyle().Faint(true)
 	BoldStyle   = lipgloss.NewStyle().Bold(true)
 	CursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("4")) // blue
+
+	TextCursorStyle = lipgloss.NewStyle().Reverse(true)
 )
 
-// ColorStyle returns a lipgloss style for the given color name.
+// ColorStyle returns a lipgloss style for the given color name, ANSI
+// color number or hex code.
 func ColorStyle(c string) lipgloss.Style {
 	switch c {
 	case "green":
@@ -30,8 +33,10 @@ func ColorStyle(c string) lipgloss.Style {
 		return lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
 	case "gray":
 		return lipgloss.NewStyle().Faint(true)
-	default:
+	case "":
 		return lipgloss.NewStyle()
+	default:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(c))
 	}
 }
 
@@ -58,5 +63,9 @@ func (m Model) RenderHeader(breadcrumbs []string, hint string) string {
 
 // RenderFooter renders the footer bar with help text.
 func (m Model) RenderFooter(text string) string {
-	return " " + DimStyle.Render(text) + "\n"
+	footer := " " + DimStyle.Render(text) + "\n"
+	if m.EditorErr != nil {
+		footer = " " + ColorStyle("red").Render("Editor: "+m.EditorErr.Error()) + "\n" + footer
+	}
+	return footer
 }
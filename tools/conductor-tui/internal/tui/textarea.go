@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TextArea is a multi-line text input. Lines are wrapped at word
+// boundaries the way util.Wrap wraps them, and Up/Down move the cursor
+// between the wrapped rows.
+type TextArea struct {
+	Value  []rune
+	Cursor int // rune offset into Value
+	Width  int // wrap width in columns
+}
+
+// NewTextArea returns a text area holding s with the cursor at the end.
+func NewTextArea(s string, width int) TextArea {
+	v := []rune(s)
+	return TextArea{Value: v, Cursor: len(v), Width: width}
+}
+
+// String returns the text.
+func (t TextArea) String() string {
+	return string(t.Value)
+}
+
+// textRow is one wrapped row: Value[start:end]. A row that was wrapped
+// keeps the space it broke at; last marks the final row of a line, whose
+// end is a newline or the end of the text.
+type textRow struct {
+	start, end int
+	last       bool
+}
+
+// rows wraps the text into rows of at most Width columns.
+func (t TextArea) rows() []textRow {
+	width := max(t.Width, 1)
+	var rows []textRow
+	start := 0
+	for start <= len(t.Value) {
+		end := start
+		for end < len(t.Value) && t.Value[end] != '\n' {
+			end++
+		}
+		for end-start > width {
+			brk := -1
+			for i := start + width; i > start; i-- {
+				if t.Value[i] == ' ' {
+					brk = i
+					break
+				}
+			}
+			if brk < 0 {
+				rows = append(rows, textRow{start, start + width, false})
+				start += width
+			} else {
+				rows = append(rows, textRow{start, brk + 1, false})
+				start = brk + 1
+			}
+		}
+		rows = append(rows, textRow{start, end, true})
+		start = end + 1
+	}
+	return rows
+}
+
+// position returns the row and column of the cursor.
+func (t TextArea) position(rows []textRow) (int, int) {
+	for i, r := range rows {
+		if t.Cursor >= r.start && (t.Cursor < r.end || r.last && t.Cursor == r.end) {
+			return i, t.Cursor - r.start
+		}
+	}
+	last := len(rows) - 1
+	return last, rows[last].end - rows[last].start
+}
+
+// rowEnd returns the last cursor offset on r: wrapped rows end before the
+// offset that starts the next row.
+func rowEnd(r textRow) int {
+	if r.last {
+		return r.end
+	}
+	return r.end - 1
+}
+
+// Update applies an editing or cursor key. Enter inserts a newline and
+// pasted text is inserted as is, with CR/LF line endings normalised.
+func (t *TextArea) Update(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyRunes, tea.KeySpace:
+		s := strings.ReplaceAll(string(msg.Runes), "\r\n", "\n")
+		s = strings.ReplaceAll(strings.ReplaceAll(s, "\r", "\n"), "\t", "    ")
+		t.insert([]rune(s))
+	case tea.KeyEnter:
+		t.insert([]rune{'\n'})
+	case tea.KeyBackspace:
+		if t.Cursor > 0 {
+			t.Value = append(t.Value[:t.Cursor-1], t.Value[t.Cursor:]...)
+			t.Cursor--
+		}
+	case tea.KeyDelete:
+		if t.Cursor < len(t.Value) {
+			t.Value = append(t.Value[:t.Cursor], t.Value[t.Cursor+1:]...)
+		}
+	case tea.KeyLeft:
+		t.Cursor = max(t.Cursor-1, 0)
+	case tea.KeyRight:
+		t.Cursor = min(t.Cursor+1, len(t.Value))
+	case tea.KeyUp, tea.KeyDown:
+		rows := t.rows()
+		row, col := t.position(rows)
+		if msg.Type == tea.KeyUp {
+			row--
+		} else {
+			row++
+		}
+		if row >= 0 && row < len(rows) {
+			r := rows[row]
+			t.Cursor = min(r.start+col, rowEnd(r))
+		}
+	case tea.KeyHome, tea.KeyCtrlA:
+		rows := t.rows()
+		row, _ := t.position(rows)
+		t.Cursor = rows[row].start
+	case tea.KeyEnd, tea.KeyCtrlE:
+		rows := t.rows()
+		row, _ := t.position(rows)
+		t.Cursor = rowEnd(rows[row])
+	}
+}
+
+func (t *TextArea) insert(r []rune) {
+	v := make([]rune, 0, len(t.Value)+len(r))
+	v = append(v, t.Value[:t.Cursor]...)
+	v = append(v, r...)
+	v = append(v, t.Value[t.Cursor:]...)
+	t.Value = v
+	t.Cursor += len(r)
+}
+
+// View renders the wrapped rows with the cursor shown in reverse video.
+// Rows after the first are prefixed with indent.
+func (t TextArea) View(indent string) string {
+	rows := t.rows()
+	crow, ccol := t.position(rows)
+	lines := make([]string, len(rows))
+	for i, r := range rows {
+		text := string(t.Value[r.start:r.end])
+		if i == crow {
+			runes := []rune(text)
+			under := " "
+			if ccol < len(runes) {
+				under = string(runes[ccol])
+				text = string(runes[:ccol]) + TextCursorStyle.Render(under) + string(runes[ccol+1:])
+			} else {
+				text += TextCursorStyle.Render(under)
+			}
+		}
+		if i > 0 {
+			text = indent + text
+		}
+		lines[i] = text
+	}
+	return strings.Join(lines, "\n")
+}
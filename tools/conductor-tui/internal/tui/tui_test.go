@@ -1,14 +1,30 @@
 package tui
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
-	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/data"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/git"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/history"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/hooks"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/revert"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/webhook"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
 )
 
 func TestNewModel_InitialState(t *testing.T) {
@@ -59,28 +75,28 @@ func TestScreen_TracksIsDefault(t *testing.T) {
 // Helper to create a model populated with test tracks.
 func testModelWithTracks() Model {
 	m := NewModel(".")
-	m.AllTracks = []data.Track{
+	m.AllTracks = []conductor.Track{
 		{TrackID: "feature-auth", Type: "feature", Status: "in_progress", Source: "active",
-			Phases: []data.Phase{
-				{Number: 1, Name: "Setup", Tasks: []data.Task{
+			Phases: []conductor.Phase{
+				{Number: 1, Name: "Setup", Tasks: []conductor.Task{
 					{Name: "Init project", Completed: true, Commit: "abc1234"},
-					{Name: "Add deps", Completed: false, SubTasks: []data.SubTask{
+					{Name: "Add deps", Completed: false, SubTasks: []conductor.SubTask{
 						{Name: "Add framework", Completed: true},
 						{Name: "Add linter", Completed: false},
 					}},
 				}},
-				{Number: 2, Name: "Implementation", Tasks: []data.Task{
+				{Number: 2, Name: "Implementation", Tasks: []conductor.Task{
 					{Name: "Build API", Completed: false},
 				}},
 			}},
 		{TrackID: "bugfix-login", Type: "bug", Status: "done", Source: "active",
-			Phases: []data.Phase{
-				{Number: 1, Name: "Fix", Tasks: []data.Task{
+			Phases: []conductor.Phase{
+				{Number: 1, Name: "Fix", Tasks: []conductor.Task{
 					{Name: "Fix login bug", Completed: true, Commit: "def5678"},
 				}},
 			}},
 		{TrackID: "feature-old", Type: "feature", Status: "done", Source: "archived",
-			Phases: []data.Phase{}},
+			Phases: []conductor.Phase{}},
 	}
 	return m
 }
@@ -531,9 +547,9 @@ func TestUpdate_WindowSizeMsg(t *testing.T) {
 
 func TestUpdate_TracksLoadedMsg(t *testing.T) {
 	m := NewModel(".")
-	newTracks := []data.Track{{TrackID: "test-track", Source: "active"}}
+	newTracks := []conductor.Track{{TrackID: "test-track", Source: "active"}}
 
-	result, _ := m.Update(TracksLoadedMsg(newTracks))
+	result, _ := m.Update(TracksLoadedMsg{Tracks: newTracks})
 	updated := result.(Model)
 
 	if len(updated.AllTracks) != 1 {
@@ -544,6 +560,28 @@ func TestUpdate_TracksLoadedMsg(t *testing.T) {
 	}
 }
 
+func TestUpdate_TracksLoadedMsgStaleSource(t *testing.T) {
+	m := NewModel(".")
+	m.AllTracks = []conductor.Track{{TrackID: "on-main", Source: "active"}}
+	m.Branch = "main"
+	stale := []conductor.Track{{TrackID: "working-tree", Source: "active"}}
+
+	result, _ := m.Update(TracksLoadedMsg{Tracks: stale})
+	if got := result.(Model).AllTracks[0].TrackID; got != "on-main" {
+		t.Errorf("working tree load after switching to a branch replaced tracks with %q", got)
+	}
+
+	result, _ = m.Update(TracksLoadedMsg{Tracks: stale, Branch: "main", MergeWorktrees: true})
+	if got := result.(Model).AllTracks[0].TrackID; got != "on-main" {
+		t.Errorf("merged worktree load after leaving merge mode replaced tracks with %q", got)
+	}
+
+	result, _ = m.Update(WorktreeTracksLoadedMsg{Tracks: stale})
+	if got := result.(Model).AllTracks[0].TrackID; got != "on-main" {
+		t.Errorf("worktree load after leaving merge mode replaced tracks with %q", got)
+	}
+}
+
 // --- View Tests ---
 
 func TestViewTracks_EmptyState(t *testing.T) {
@@ -686,14 +724,14 @@ func TestViewDetail_Content(t *testing.T) {
 func TestViewDetail_ScrollIndicators(t *testing.T) {
 	m := testModelWithTracks()
 	// Create a track with many sub-tasks to force scrolling
-	m.AllTracks = []data.Track{
+	m.AllTracks = []conductor.Track{
 		{TrackID: "many-subs", Type: "feature", Status: "in_progress", Source: "active",
-			Phases: []data.Phase{
-				{Number: 1, Name: "Phase", Tasks: []data.Task{
-					{Name: "Task with many subs", SubTasks: func() []data.SubTask {
-						subs := make([]data.SubTask, 30)
+			Phases: []conductor.Phase{
+				{Number: 1, Name: "Phase", Tasks: []conductor.Task{
+					{Name: "Task with many subs", SubTasks: func() []conductor.SubTask {
+						subs := make([]conductor.SubTask, 30)
 						for i := range subs {
-							subs[i] = data.SubTask{Name: fmt.Sprintf("Sub-task %d", i+1)}
+							subs[i] = conductor.SubTask{Name: fmt.Sprintf("Sub-task %d", i+1)}
 						}
 						return subs
 					}()},
@@ -885,15 +923,15 @@ func TestMoveEditField_ClampAtBounds(t *testing.T) {
 	}
 
 	// Move to last field
-	m.MoveEditField(1)
-	if m.CurrentScreen().EditFieldIdx != 1 {
-		t.Errorf("EditFieldIdx = %d, want 1", m.CurrentScreen().EditFieldIdx)
+	m.MoveEditField(2)
+	if m.CurrentScreen().EditFieldIdx != EditFieldDescription {
+		t.Errorf("EditFieldIdx = %d, want %d", m.CurrentScreen().EditFieldIdx, EditFieldDescription)
 	}
 
 	// Try to go past last field
 	m.MoveEditField(1)
-	if m.CurrentScreen().EditFieldIdx != 1 {
-		t.Errorf("EditFieldIdx = %d, want 1 (clamped at bottom)", m.CurrentScreen().EditFieldIdx)
+	if m.CurrentScreen().EditFieldIdx != EditFieldDescription {
+		t.Errorf("EditFieldIdx = %d, want %d (clamped at bottom)", m.CurrentScreen().EditFieldIdx, EditFieldDescription)
 	}
 }
 
@@ -916,8 +954,18 @@ func TestHandleKey_LeftCyclesFieldBackward_WhenEditing(t *testing.T) {
 	m := testModelWithTracks()
 	m.Stack = append(m.Stack, Screen{ScreenType: ScreenEdit, TrackIdx: 0, EditFieldIdx: 0, Editing: true})
 
+	// Moving back from in_progress to new needs confirmation.
 	result, _ := m.HandleKey(tea.KeyMsg{Type: tea.KeyLeft})
 	updated := result.(Model)
+	if !updated.CurrentScreen().Confirming || updated.Tracks()[0].Status != "in_progress" {
+		t.Fatal("left arrow should ask before moving the status back")
+	}
+	if !strings.Contains(updated.ViewEdit(), "Move status back from in_progress to new?") {
+		t.Error("edit view should show the confirmation prompt")
+	}
+
+	result, _ = updated.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	updated = result.(Model)
 
 	track := updated.Tracks()[updated.CurrentScreen().TrackIdx]
 	if track.Status == "in_progress" {
@@ -1011,7 +1059,7 @@ func TestHandleKey_RightOnEditWhileEditingCyclesAndSaves(t *testing.T) {
 
 func TestMetadataPath_ActiveTrack(t *testing.T) {
 	m := NewModel("/project")
-	m.AllTracks = []data.Track{
+	m.AllTracks = []conductor.Track{
 		{TrackID: "feature-test", Source: "active"},
 	}
 
@@ -1024,7 +1072,7 @@ func TestMetadataPath_ActiveTrack(t *testing.T) {
 
 func TestMetadataPath_ArchivedTrack(t *testing.T) {
 	m := NewModel("/project")
-	m.AllTracks = []data.Track{
+	m.AllTracks = []conductor.Track{
 		{TrackID: "old-track", Source: "archived"},
 	}
 	m.ShowArchived = true
@@ -1051,7 +1099,7 @@ func TestPersistence_CycleAndSave(t *testing.T) {
 	}
 
 	m := NewModel(dir)
-	m.AllTracks = data.DiscoverTracks(dir)
+	m.AllTracks = conductor.DiscoverTracks(dir)
 
 	if len(m.AllTracks) != 1 {
 		t.Fatalf("expected 1 track, got %d", len(m.AllTracks))
@@ -1070,7 +1118,7 @@ func TestPersistence_CycleAndSave(t *testing.T) {
 		t.Fatalf("failed to read saved metadata: %v", err)
 	}
 
-	savedTrack, err := data.LoadMetadata(savedData)
+	savedTrack, err := conductor.LoadMetadata(savedData)
 	if err != nil {
 		t.Fatalf("failed to parse saved metadata: %v", err)
 	}
@@ -1251,3 +1299,1086 @@ func TestColorStyle_UnknownReturnsDefault(t *testing.T) {
 		t.Error("unknown color should still render text")
 	}
 }
+
+// --- History Screen Tests ---
+
+func TestHandleKey_HOnPhasesPushesHistory(t *testing.T) {
+	m := testModelWithTracks()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenPhases, TrackIdx: 0})
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	updated := result.(Model)
+
+	if updated.CurrentScreen().ScreenType != ScreenHistory {
+		t.Fatalf("expected history screen, got %d", updated.CurrentScreen().ScreenType)
+	}
+	if cmd == nil {
+		t.Error("expected a command to load history")
+	}
+}
+
+func TestViewHistory_Content(t *testing.T) {
+	m := testModelWithTracks()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenHistory, TrackIdx: 0})
+
+	output := m.ViewHistory()
+	if !strings.Contains(output, "Reading git history") {
+		t.Error("history view should show loading state before report arrives")
+	}
+
+	t0 := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	result, _ := m.Update(HistoryLoadedMsg{
+		TrackID: "feature-auth",
+		Report: history.Report{
+			Cycles: []history.TaskCycle{{Phase: 1, Task: "Init project", Started: t0, Done: t0.Add(3 * time.Hour)}},
+			Burndown: []history.Point{
+				{Time: t0, Total: 2, Remaining: 2},
+				{Time: t0.Add(3 * time.Hour), Total: 2, Remaining: 1},
+			},
+			Throughput: []history.Week{{Start: t0, Completed: 1, MeanCycle: 3 * time.Hour}},
+		},
+	})
+	output = result.(Model).ViewHistory()
+
+	if !strings.Contains(output, "Burndown") {
+		t.Error("history view should contain burndown chart")
+	}
+	if !strings.Contains(output, "Init project") {
+		t.Error("history view should list task cycle times")
+	}
+	if !strings.Contains(output, "3h 0m") {
+		t.Error("history view should show formatted cycle time")
+	}
+}
+
+func TestUpdate_HistoryLoadedMsgForOtherTrack(t *testing.T) {
+	m := testModelWithTracks()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenHistory, TrackIdx: 0})
+
+	result, _ := m.Update(HistoryLoadedMsg{TrackID: "bugfix-login", Err: fmt.Errorf("not a git repository")})
+	updated := result.(Model)
+	if updated.HistoryTrack != "" || updated.HistoryErr != nil {
+		t.Errorf("history of a track no longer shown was applied: track %q, err %v", updated.HistoryTrack, updated.HistoryErr)
+	}
+	if !strings.Contains(updated.ViewHistory(), "Reading git history") {
+		t.Error("history view should keep waiting for its own track")
+	}
+}
+
+func TestViewHistory_Error(t *testing.T) {
+	m := testModelWithTracks()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenHistory, TrackIdx: 0})
+	m.HistoryErr = fmt.Errorf("not a git repository")
+
+	output := m.ViewHistory()
+	if !strings.Contains(output, "not a git repository") {
+		t.Error("history view should show the error")
+	}
+}
+
+// --- Branch Selector Tests ---
+
+func TestHandleKey_BOnTracksPushesBranches(t *testing.T) {
+	m := testModelWithTracks()
+	m.Branches = []string{"feature", "main"}
+	m.Branch = "main"
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	updated := result.(Model)
+
+	if updated.CurrentScreen().ScreenType != ScreenBranches {
+		t.Fatalf("expected branches screen, got %d", updated.CurrentScreen().ScreenType)
+	}
+	if updated.CurrentScreen().Cursor != 2 {
+		t.Errorf("cursor = %d, want 2 (on the selected branch)", updated.CurrentScreen().Cursor)
+	}
+	if cmd == nil {
+		t.Error("expected a command to refresh branches")
+	}
+}
+
+func TestHandleKey_EnterOnBranchesSelectsRef(t *testing.T) {
+	m := testModelWithTracks()
+	m.Branches = []string{"feature", "main"}
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenBranches, Cursor: 1})
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	updated := result.(Model)
+
+	if updated.Branch != "feature" {
+		t.Errorf("Branch = %q, want %q", updated.Branch, "feature")
+	}
+	if len(updated.Stack) != 1 || updated.CurrentScreen().ScreenType != ScreenTracks {
+		t.Error("selecting a branch should return to the tracks screen")
+	}
+	if cmd == nil {
+		t.Error("expected a command to reload tracks")
+	}
+
+	// Cursor 0 returns to the working tree.
+	updated.Stack = append(updated.Stack, Screen{ScreenType: ScreenBranches, Cursor: 0})
+	result, _ = updated.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if result.(Model).Branch != "" {
+		t.Errorf("Branch = %q, want working tree", result.(Model).Branch)
+	}
+}
+
+func TestHandleKey_EditDisabledOnBranch(t *testing.T) {
+	m := testModelWithTracks()
+	m.Branch = "feature"
+
+	result, _ := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if result.(Model).CurrentScreen().ScreenType == ScreenEdit {
+		t.Error("edit should be disabled when viewing another branch")
+	}
+}
+
+func TestViewTracks_BranchColumn(t *testing.T) {
+	m := testModelWithTracks()
+	m.Width = 140
+	m.BranchProgress = map[string]git.Progress{
+		"feature-auth": {Branch: "feat/auth", Done: 3, Total: 5},
+	}
+
+	output := m.ViewTracks()
+	if !strings.Contains(output, "Branch") {
+		t.Error("tracks view should have a Branch column")
+	}
+	if !strings.Contains(output, "feat/auth 3/5") {
+		t.Error("tracks view should show the most advanced branch with progress")
+	}
+}
+
+func TestViewBranches_Content(t *testing.T) {
+	m := testModelWithTracks()
+	m.Branches = []string{"feature", "origin/main"}
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenBranches})
+
+	output := m.ViewBranches()
+	for _, want := range []string{"(working tree)", "feature", "origin/main"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("branches view should contain %q", want)
+		}
+	}
+}
+
+// --- Worktree View Tests ---
+
+func TestHandleKey_WTogglesMergedWorktrees(t *testing.T) {
+	m := testModelWithTracks()
+	m.Branch = "feature"
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	updated := result.(Model)
+
+	if !updated.MergeWorktrees {
+		t.Error("MergeWorktrees should be enabled after pressing w")
+	}
+	if updated.Branch != "" {
+		t.Error("merged worktree view should reset the branch selection")
+	}
+	if cmd == nil {
+		t.Error("expected a command to reload tracks")
+	}
+}
+
+func TestUpdate_WorktreeTracksLoadedMsg(t *testing.T) {
+	m := NewModel("/repo/project")
+	m.MergeWorktrees = true
+	origins := map[string]git.TrackOrigin{
+		"feature-auth": {Worktree: git.Worktree{Path: "/wt/auth-session", Branch: "auth"}, BasePath: "/wt/auth-session/project"},
+	}
+	result, _ := m.Update(WorktreeTracksLoadedMsg{
+		Tracks:  []conductor.Track{{TrackID: "feature-auth", Status: "in_progress", Source: "active"}},
+		Origins: origins,
+	})
+	updated := result.(Model)
+
+	if len(updated.AllTracks) != 1 {
+		t.Fatalf("got %d tracks, want 1", len(updated.AllTracks))
+	}
+	want := "/wt/auth-session/project/conductor/tracks/feature-auth/metadata.json"
+	if got := updated.MetadataPath(0); got != want {
+		t.Errorf("MetadataPath = %q, want %q (origin worktree)", got, want)
+	}
+
+	updated.Width = 140
+	output := updated.ViewTracks()
+	if !strings.Contains(output, "Worktree") || !strings.Contains(output, "auth-session") {
+		t.Error("tracks view should show the worktree each track was taken from")
+	}
+}
+
+// --- Revert Screen Tests ---
+
+func TestHandleKey_RPushesRevertForScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		screen    Screen
+		wantPhase int
+		wantTask  int
+	}{
+		{"track", Screen{ScreenType: ScreenTracks, Cursor: 1}, -1, -1},
+		{"phase", Screen{ScreenType: ScreenPhases, TrackIdx: 0, Cursor: 1}, 1, -1},
+		{"task", Screen{ScreenType: ScreenTasks, TrackIdx: 0, PhaseIdx: 0, Cursor: 1}, 0, 1},
+	}
+	for _, tt := range tests {
+		m := testModelWithTracks()
+		m.Stack = []Screen{tt.screen}
+
+		result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+		s := result.(Model).CurrentScreen()
+
+		if s.ScreenType != ScreenRevert {
+			t.Fatalf("%s: expected revert screen, got %d", tt.name, s.ScreenType)
+		}
+		if s.PhaseIdx != tt.wantPhase || s.TaskIdx != tt.wantTask {
+			t.Errorf("%s: PhaseIdx/TaskIdx = %d/%d, want %d/%d", tt.name, s.PhaseIdx, s.TaskIdx, tt.wantPhase, tt.wantTask)
+		}
+		if cmd == nil {
+			t.Errorf("%s: expected a command to build the plan", tt.name)
+		}
+	}
+}
+
+func testRevertModel() Model {
+	m := testModelWithTracks()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenRevert, TrackIdx: 0, PhaseIdx: 0, TaskIdx: 0})
+	result, _ := m.Update(RevertPlanMsg{Plan: revert.Plan{
+		Target: "Task 'Init project'",
+		Commits: []revert.Commit{
+			{SHA: "1111111aaaaaaa", Subject: "conductor(plan): mark task complete", Reason: "plan update"},
+			{SHA: "abc1234bbbbbbb", Subject: "feat: init project", Reason: "task"},
+		},
+	}})
+	return result.(Model)
+}
+
+func TestViewRevert_DryRun(t *testing.T) {
+	m := testRevertModel()
+	output := m.ViewRevert()
+
+	for _, want := range []string{"Task 'Init project'", "Commits to revert: 2", "abc1234", "feat: init project", "[x] Execute revert"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("revert view should contain %q", want)
+		}
+	}
+}
+
+func TestHandleKey_RevertRequiresConfirmation(t *testing.T) {
+	m := testRevertModel()
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	updated := result.(Model)
+	if !updated.CurrentScreen().Confirming {
+		t.Fatal("x should open the confirmation prompt")
+	}
+	if cmd != nil {
+		t.Error("x must not run git revert before confirmation")
+	}
+	if !strings.Contains(updated.ViewRevert(), "Run git revert on 2 commits?") {
+		t.Error("confirmation prompt should be shown")
+	}
+
+	// n cancels without running anything.
+	result, cmd = updated.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if result.(Model).CurrentScreen().Confirming || cmd != nil {
+		t.Error("n should dismiss the prompt without running git revert")
+	}
+
+	// y runs the revert.
+	result, _ = result.(Model).HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	result, cmd = result.(Model).HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if !result.(Model).RevertRunning || cmd == nil {
+		t.Error("y should start git revert")
+	}
+}
+
+func TestUpdate_RevertDoneMsg(t *testing.T) {
+	m := testRevertModel()
+	result, _ := m.Update(RevertDoneMsg{})
+	output := result.(Model).ViewRevert()
+	if !strings.Contains(output, "Reverted 2 commits.") {
+		t.Error("revert view should report success")
+	}
+
+	result, _ = m.Update(RevertDoneMsg{Err: fmt.Errorf("conflict in first.txt")})
+	if !strings.Contains(result.(Model).ViewRevert(), "conflict in first.txt") {
+		t.Error("revert view should report the failure")
+	}
+}
+
+// --- Blame Overlay Tests ---
+
+func TestHandleKey_BOnTasksTogglesBlame(t *testing.T) {
+	m := testModelWithTracks()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenTasks, TrackIdx: 0, PhaseIdx: 0})
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	updated := result.(Model)
+	if !updated.ShowBlame {
+		t.Fatal("b on tasks should enable the blame overlay")
+	}
+	if cmd == nil {
+		t.Error("enabling blame should load it")
+	}
+	if updated.CurrentScreen().ScreenType != ScreenTasks {
+		t.Error("b on tasks must not open the branch selector")
+	}
+
+	result, _ = updated.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+	if result.(Model).ShowBlame {
+		t.Error("second b should hide the blame overlay")
+	}
+}
+
+func TestViewTasks_BlameOverlay(t *testing.T) {
+	m := testModelWithTracks()
+	m.AllTracks[0].Phases[0].Tasks[0].Line = 5
+	m.AllTracks[0].Phases[0].Tasks[1].Line = 6
+	m.Width = 120
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenTasks, TrackIdx: 0, PhaseIdx: 0})
+	m.ShowBlame = true
+	result, _ := m.Update(BlameLoadedMsg{
+		Path: m.PlanPath(0),
+		Lines: map[int]git.BlameLine{
+			5: {Commit: "abc1234def5678abc1234def5678abc1234def56", Author: "Alice",
+				AuthorTime: time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)},
+			6: {Commit: strings.Repeat("0", 40), Author: "Not Committed Yet"},
+		},
+	})
+	output := result.(Model).ViewTasks()
+
+	if !strings.Contains(output, "Last changed by") {
+		t.Error("blame overlay should replace the Commit column header")
+	}
+	if !strings.Contains(output, "abc1234 Alice 2026-03-02") {
+		t.Error("blame overlay should show commit, author and date")
+	}
+	if !strings.Contains(output, "uncommitted") {
+		t.Error("blame overlay should mark uncommitted lines")
+	}
+}
+
+func TestViewDetail_BlameOverlay(t *testing.T) {
+	m := testModelWithTracks()
+	m.AllTracks[0].Phases[0].Tasks[1].Line = 6
+	m.AllTracks[0].Phases[0].Tasks[1].SubTasks[0].Line = 7
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenDetail, TrackIdx: 0, PhaseIdx: 0, TaskIdx: 1})
+	m.ShowBlame = true
+	m.BlamePath = m.PlanPath(0)
+	m.Blame = map[int]git.BlameLine{
+		6: {Commit: "1111111222222233333334444444555555566666", Author: "Bob", AuthorTime: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		7: {Commit: "7777777222222233333334444444555555566666", Author: "Carol", AuthorTime: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)},
+	}
+
+	output := m.ViewDetail()
+	if !strings.Contains(output, "Last changed by: 1111111 Bob 2026-03-01") {
+		t.Error("detail should show who last changed the task line")
+	}
+	if !strings.Contains(output, "7777777 Carol 2026-03-03") {
+		t.Error("detail should show blame for sub-tasks")
+	}
+}
+
+func TestHandleKey_XOnTracksOpensExport(t *testing.T) {
+	m := testModelWithTracks()
+	m.Stack[0].Cursor = 1
+
+	result, _ := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	updated := result.(Model)
+	s := updated.CurrentScreen()
+	if s.ScreenType != ScreenExport || s.TrackIdx != 1 {
+		t.Fatalf("screen = %d, track = %d; want export for track 1", s.ScreenType, s.TrackIdx)
+	}
+	if !updated.ExportAll {
+		t.Error("export should default to all listed tracks")
+	}
+
+	result, _ = updated.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")})
+	updated = result.(Model)
+	if updated.ExportAll {
+		t.Error("t should toggle to the selected track")
+	}
+	if !strings.Contains(updated.ViewExport(), "Tracks: bugfix-login") {
+		t.Error("export view should name the selected track")
+	}
+}
+
+func TestExport_WritesSelectedTrack(t *testing.T) {
+	m := testModelWithTracks()
+	m.BasePath = t.TempDir()
+	m.ExportAll = false
+	s := Screen{ScreenType: ScreenExport, TrackIdx: 0, Cursor: 3} // CSV per phase
+
+	msg := m.Export(s)().(ExportDoneMsg)
+	if msg.Err != nil {
+		t.Fatalf("export failed: %v", msg.Err)
+	}
+	if !strings.HasSuffix(msg.Path, "-phase.csv") {
+		t.Errorf("path = %q, want a phase CSV", msg.Path)
+	}
+	out, err := os.ReadFile(msg.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "feature-auth,1,Setup,") {
+		t.Errorf("unexpected CSV:\n%s", out)
+	}
+
+	result, _ := m.Update(msg)
+	if !strings.Contains(result.(Model).ViewExport(), "Wrote "+msg.Path) {
+		t.Error("export view should report the written file")
+	}
+}
+
+func TestUpdate_TracksLoadedRunsHooks(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	m := testModelWithTracks()
+	m.Hooks = hooks.NewRunner(t.TempDir(), config.Hooks{
+		Timeout:     config.Duration(5 * time.Second),
+		Concurrency: 1,
+		Commands:    []config.Hook{{On: "track_status_changed", To: "completed", Run: `echo "done $CONDUCTOR_TRACK_ID"`}},
+	})
+
+	// The first load only records the snapshot.
+	result, cmd := m.Update(TracksLoadedMsg{Tracks: m.AllTracks})
+	m = result.(Model)
+	if cmd != nil {
+		t.Fatal("first load should not run hooks")
+	}
+
+	next := append([]conductor.Track(nil), m.AllTracks...)
+	next[0].Status = "completed"
+	result, cmd = m.Update(TracksLoadedMsg{Tracks: next})
+	m = result.(Model)
+	if cmd == nil || m.HooksRunning != 1 {
+		t.Fatalf("expected one hook to run, running = %d", m.HooksRunning)
+	}
+
+	// tea.Batch of a single command returns it directly.
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		msg = batch[0]()
+	}
+	result, _ = m.Update(msg)
+	m = result.(Model)
+	if m.HooksRunning != 0 || len(m.HookLog) != 1 {
+		t.Fatalf("running = %d, log = %d", m.HooksRunning, len(m.HookLog))
+	}
+
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenLog})
+	view := m.ViewLog()
+	if !strings.Contains(view, "done feature-auth") || !strings.Contains(view, "exit 0") {
+		t.Errorf("log panel should show output and exit code:\n%s", view)
+	}
+}
+
+func TestUpdate_TracksLoadedSendsWebhooks(t *testing.T) {
+	got := make(chan events.Event, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e events.Event
+		json.NewDecoder(r.Body).Decode(&e)
+		got <- e
+	}))
+	defer srv.Close()
+
+	m := testModelWithTracks()
+	m.Webhooks = webhook.New(t.TempDir(), config.Webhooks{
+		Endpoints:   []config.Endpoint{{URL: srv.URL, Events: []string{events.TrackStatusChanged}}},
+		Outbox:      config.DefaultOutbox,
+		MaxAttempts: 3,
+	})
+	result, _ := m.Update(TracksLoadedMsg{Tracks: m.AllTracks})
+	m = result.(Model)
+
+	next := append([]conductor.Track(nil), m.AllTracks...)
+	next[0].Status = "completed"
+	result, cmd := m.Update(TracksLoadedMsg{Tracks: next})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("expected a webhook flush")
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		msg = batch[0]()
+	}
+	result, _ = m.Update(msg)
+	m = result.(Model)
+	if m.WebhookResult.Delivered != 1 || len(got) != 1 {
+		t.Fatalf("result = %+v, received %d events", m.WebhookResult, len(got))
+	}
+	if e := <-got; e.Type != events.TrackStatusChanged || e.To != "completed" {
+		t.Errorf("received %+v", e)
+	}
+
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenLog})
+	if !strings.Contains(m.ViewLog(), "Webhooks: 1 endpoints, 0 pending") {
+		t.Errorf("log panel should show webhook status:\n%s", m.ViewLog())
+	}
+}
+
+func TestHandleKey_LOpensHookLog(t *testing.T) {
+	m := testModelWithTracks()
+	result, _ := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if result.(Model).CurrentScreen().ScreenType != ScreenLog {
+		t.Error("l should open the hook log")
+	}
+	if !strings.Contains(result.(Model).ViewLog(), "No hooks have run yet") {
+		t.Error("empty log should say so")
+	}
+}
+
+func TestHandleKey_NOpensNewTrackForm(t *testing.T) {
+	m := testModelWithTracks()
+	m.BasePath = t.TempDir()
+
+	result, _ := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = result.(Model)
+	if m.CurrentScreen().ScreenType != ScreenNewTrack || m.NewTrack.Type != TypeValues[0] {
+		t.Fatalf("screen = %d, form = %+v", m.CurrentScreen().ScreenType, m.NewTrack)
+	}
+
+	// Keys that are actions elsewhere type into the form.
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("quick-fix")},
+		{Type: tea.KeyTab},
+		{Type: tea.KeyRight},
+		{Type: tea.KeyDown},
+		{Type: tea.KeyRunes, Runes: []rune("Fix")},
+		{Type: tea.KeySpace, Runes: []rune(" ")},
+		{Type: tea.KeyRunes, Runes: []rune("itx")},
+		{Type: tea.KeyBackspace},
+	} {
+		result, _ = m.HandleKey(msg)
+		m = result.(Model)
+	}
+	if m.NewTrack != (NewTrackForm{ShortName: "quick-fix", Type: TypeValues[1], Description: "Fix it"}) {
+		t.Fatalf("form = %+v", m.NewTrack)
+	}
+	if view := m.ViewNewTrack(); !strings.Contains(view, "quick-fix_"+time.Now().Format("20060102")) {
+		t.Errorf("view should preview the track ID:\n%s", view)
+	}
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	msg := cmd().(TrackCreatedMsg)
+	if msg.Err != nil {
+		t.Fatalf("create failed: %v", msg.Err)
+	}
+	result, _ = result.(Model).Update(msg)
+	m = result.(Model)
+	if len(m.Stack) != 1 || m.Stack[0].Cursor != 0 {
+		t.Errorf("stack = %+v, want the tracks list", m.Stack)
+	}
+	entries, err := conductor.New(m.BasePath).Registry()
+	if err != nil || len(entries) != 1 || entries[0].TrackID != msg.Track.TrackID {
+		t.Errorf("registry = %+v, %v", entries, err)
+	}
+}
+
+func TestNewTrackForm_ShowsError(t *testing.T) {
+	m := testModelWithTracks()
+	m.BasePath = t.TempDir()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenNewTrack})
+	m.NewTrack = NewTrackForm{ShortName: "Bad Name", Type: "feature", Description: "x"}
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	result, _ = result.(Model).Update(cmd())
+	m = result.(Model)
+	if m.CurrentScreen().ScreenType != ScreenNewTrack || !strings.Contains(m.ViewNewTrack(), "Error: short name") {
+		t.Errorf("form should stay open with the error:\n%s", m.ViewNewTrack())
+	}
+}
+
+func TestHandleKey_ArchiveConfirmsAndMovesTrack(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(base)
+	m.AllTracks = conductor.DiscoverTracks(base)
+
+	result, _ := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	m = result.(Model)
+	if m.CurrentScreen().ScreenType != ScreenArchive {
+		t.Fatalf("screen = %d, want archive confirmation", m.CurrentScreen().ScreenType)
+	}
+	track := m.Tracks()[0]
+	view := m.ViewArchive()
+	for _, want := range []string{"conductor/tracks/" + track.TrackID + "/", "conductor/archive/" + track.TrackID + "/", "metadata.json", "keep " + track.Status} {
+		if !strings.Contains(view, want) {
+			t.Errorf("confirmation should show %q:\n%s", want, view)
+		}
+	}
+
+	result, _ = m.HandleKey(tea.KeyMsg{Type: tea.KeyRight})
+	result, _ = result.(Model).HandleKey(tea.KeyMsg{Type: tea.KeyRight})
+	m = result.(Model)
+	if m.ArchiveStatus != StatusValues[1] {
+		t.Errorf("status = %q, want %q", m.ArchiveStatus, StatusValues[1])
+	}
+
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	msg := cmd().(TrackMovedMsg)
+	if msg.Err != nil {
+		t.Fatalf("archive failed: %v", msg.Err)
+	}
+	result, _ = result.(Model).Update(msg)
+	if len(result.(Model).Stack) != 1 {
+		t.Error("archiving should return to the tracks list")
+	}
+	moved, err := conductor.New(base).Track(track.TrackID)
+	if err != nil || moved.Source != "archived" || moved.Status != StatusValues[1] {
+		t.Errorf("moved track = %+v, %v", moved, err)
+	}
+}
+
+func TestHandleKey_ArchiveCancel(t *testing.T) {
+	m := testModelWithTracks()
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenArchive})
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cmd != nil || result.(Model).CurrentScreen().ScreenType != ScreenTracks {
+		t.Error("n should cancel without moving anything")
+	}
+}
+
+func TestHandleKey_RenameTrack(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(base)
+	m.AllTracks = conductor.DiscoverTracks(base)
+	old := m.Tracks()[0].TrackID
+
+	result, _ := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m = result.(Model)
+	if m.CurrentScreen().ScreenType != ScreenRename || m.RenameID != old {
+		t.Fatalf("screen = %d, id = %q", m.CurrentScreen().ScreenType, m.RenameID)
+	}
+
+	m.RenameID = "Bad ID"
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	result, _ = result.(Model).Update(cmd())
+	m = result.(Model)
+	if m.RenameErr == nil || !strings.Contains(m.ViewRename(), "Error:") {
+		t.Fatal("an invalid ID should be reported")
+	}
+
+	m.RenameID = "renamed_20260101"
+	result, cmd = m.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	result, _ = result.(Model).Update(cmd())
+	if result.(Model).CurrentScreen().ScreenType != ScreenTracks {
+		t.Error("a successful rename should return to the tracks list")
+	}
+	if _, err := conductor.New(base).Track("renamed_20260101"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTextArea_WrapsAndMovesCursor(t *testing.T) {
+	ta := NewTextArea("the quick brown fox", 10)
+	var rows []string
+	for _, r := range ta.rows() {
+		rows = append(rows, string(ta.Value[r.start:r.end]))
+	}
+	if fmt.Sprintf("%q", rows) != `["the quick " "brown fox"]` {
+		t.Fatalf("rows = %q", rows)
+	}
+	if got := strings.TrimSpace(util.Wrap(ta.String(), 10, "")); got != "the quick\nbrown fox" {
+		t.Errorf("util.Wrap = %q; the text area should break at the same words", got)
+	}
+
+	ta.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if ta.Cursor != 9 {
+		t.Errorf("cursor after Up = %d, want 9 (end of the first row)", ta.Cursor)
+	}
+	ta.Update(tea.KeyMsg{Type: tea.KeyHome})
+	ta.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("see ")})
+	ta.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	ta.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	ta.Update(tea.KeyMsg{Type: tea.KeyDown})
+	ta.Update(tea.KeyMsg{Type: tea.KeyDelete})
+	// End stops on the row's trailing space, so Backspace took the "e" of
+	// "the"; Down kept the column and landed on the space after "quick".
+	if ta.String() != "see th quickbrown fox" {
+		t.Errorf("text = %q", ta.String())
+	}
+
+	pasted := NewTextArea("", 20)
+	pasted.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("one\r\ntwo"), Paste: true})
+	pasted.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if pasted.String() != "one\ntwo\n" || len(pasted.rows()) != 3 {
+		t.Errorf("pasted = %q, rows = %v", pasted.String(), pasted.rows())
+	}
+}
+
+func TestHandleKey_EditDescriptionSavesOnConfirm(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(base)
+	m.AllTracks = conductor.DiscoverTracks(base)
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenEdit, EditFieldIdx: EditFieldDescription})
+	id, old := m.Tracks()[0].TrackID, m.Tracks()[0].Description
+
+	press := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			result, _ := m.HandleKey(msg)
+			m = result.(Model)
+		}
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" draft")})
+	if !strings.Contains(m.ViewEdit(), "[Ctrl+S] Save") {
+		t.Error("footer should explain how to save")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if got, _ := conductor.New(base).Track(id); got.Description != old || m.CurrentScreen().Editing {
+		t.Errorf("Esc should discard: description = %q", got.Description)
+	}
+
+	// q and e are text, not actions, while editing.
+	press(tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" q")},
+		tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if got, _ := conductor.New(base).Track(id); got.Description != old {
+		t.Error("nothing should be saved before Ctrl+S")
+	}
+	press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if got, _ := conductor.New(base).Track(id); got.Description != old+" q\ne" {
+		t.Errorf("saved description = %q", got.Description)
+	}
+	if m.CurrentScreen().Editing || m.CurrentScreen().ScreenType != ScreenEdit {
+		t.Error("Ctrl+S should stop editing and stay on the edit screen")
+	}
+}
+
+func TestHandleKey_EditCustomFields(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(base)
+	m.Config.Fields = []conductor.Field{
+		{Key: "estimate", Label: "Estimate", Kind: conductor.KindNumber},
+		{Key: "priority", Label: "Priority", Kind: conductor.KindEnum, Options: []string{"low", "high"}},
+	}
+	m.AllTracks = conductor.DiscoverTracks(base)
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenEdit, EditFieldIdx: EditFieldDescription + 1})
+	id := m.Tracks()[0].TrackID
+
+	press := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			result, _ := m.HandleKey(msg)
+			m = result.(Model)
+		}
+	}
+	saved := func(key string) string {
+		got, _ := conductor.New(base).Track(id)
+		return string(got.Fields[key])
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("two")}, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.CurrentScreen().Editing || !strings.Contains(m.ViewEdit(), "Estimate must be a number") {
+		t.Errorf("an invalid number should keep the input open with the error shown:\n%s", m.ViewEdit())
+	}
+	if saved("estimate") != "" {
+		t.Error("an invalid value should not be saved")
+	}
+	press(tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace}, tea.KeyMsg{Type: tea.KeyBackspace},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")}, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentScreen().Editing || saved("estimate") != "2" {
+		t.Errorf("estimate = %q, editing = %v", saved("estimate"), m.CurrentScreen().Editing)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyDown}, tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRight})
+	if saved("priority") != `"low"` {
+		t.Errorf("priority = %q, want low", saved("priority"))
+	}
+	press(tea.KeyMsg{Type: tea.KeyLeft})
+	if saved("priority") != "" || saved("estimate") != "2" {
+		t.Errorf("cycling back should unset priority and keep estimate: %q, %q", saved("priority"), saved("estimate"))
+	}
+	if !strings.Contains(m.ViewEdit(), "Priority:") {
+		t.Error("configured fields should be listed")
+	}
+}
+
+func TestConfiguredStatuses_EditAndFilter(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(base, "conductor", "tui.json"), []byte(`{"statuses": ["todo", "doing", "blocked", "done"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(base)
+	m.AllTracks = conductor.DiscoverTracks(base)
+	press := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			result, _ := m.HandleKey(msg)
+			m = result.(Model)
+		}
+	}
+
+	// bugfix-beta is "todo"; the filter cycles through the configured statuses.
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.StatusFilter != "todo" || len(m.Tracks()) != 1 || m.Tracks()[0].Status != "todo" {
+		t.Fatalf("filter %q lists %d tracks", m.StatusFilter, len(m.Tracks()))
+	}
+	if !strings.Contains(m.ViewTracks(), "status: todo") {
+		t.Error("the active filter should be shown")
+	}
+
+	id := m.Tracks()[0].TrackID
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")}, tea.KeyMsg{Type: tea.KeyEnter}, tea.KeyMsg{Type: tea.KeyRight},
+		tea.KeyMsg{Type: tea.KeyRight})
+	if got, _ := conductor.New(base).Track(id); got.Status != "blocked" {
+		t.Errorf("status = %q, want blocked", got.Status)
+	}
+
+	m.Stack = []Screen{{ScreenType: ScreenTracks}}
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.StatusFilter != "" {
+		t.Errorf("filter = %q, want cleared after a full cycle", m.StatusFilter)
+	}
+}
+
+func TestWorkflow_TransitionsAndTimestamps(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	config := `{"statuses": ["todo", "doing", "done"],
+  "workflow": {"transitions": {"todo": ["doing"], "doing": ["todo", "done"]}, "started": ["doing"], "completed": ["done"]}}`
+	if err := os.WriteFile(filepath.Join(base, "conductor", "tui.json"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(base)
+	m.AllTracks = conductor.DiscoverTracks(base)
+	idx := slices.IndexFunc(m.Tracks(), func(t conductor.Track) bool { return t.Status == "todo" })
+	id := m.Tracks()[idx].TrackID
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenEdit, TrackIdx: idx, Editing: true})
+	press := func(msgs ...tea.KeyMsg) {
+		for _, msg := range msgs {
+			result, _ := m.HandleKey(msg)
+			m = result.(Model)
+		}
+	}
+	saved := func() conductor.Track {
+		track, _ := conductor.New(base).Track(id)
+		return track
+	}
+
+	// todo may only move on to doing, so the cycle skips done.
+	if got := m.StatusTargets("todo"); !slices.Equal(got, []string{"todo", "doing"}) {
+		t.Errorf("StatusTargets(todo) = %v", got)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if got := saved(); got.Status != "doing" || got.StartedAt.IsZero() || !got.CompletedAt.IsZero() {
+		t.Fatalf("after start = %+v", got)
+	}
+	press(tea.KeyMsg{Type: tea.KeyRight})
+	if got := saved(); got.Status != "done" || got.CompletedAt.IsZero() {
+		t.Fatalf("after completion = %+v", got)
+	}
+	if !strings.Contains(m.ViewEdit(), "Completed "+saved().CompletedAt.Local().Format("2006-01-02 15:04")) {
+		t.Errorf("edit view should show the lifecycle timestamps:\n%s", m.ViewEdit())
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.CurrentScreen().Confirming || saved().Status != "done" {
+		t.Error("declining should leave the status alone")
+	}
+	press(tea.KeyMsg{Type: tea.KeyLeft}, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if got := saved(); got.Status != "doing" || !got.CompletedAt.IsZero() || got.StartedAt.IsZero() {
+		t.Errorf("after reopening = %+v", got)
+	}
+}
+
+func TestEditorArgs(t *testing.T) {
+	for _, tc := range []struct {
+		editor string
+		line   int
+		want   []string
+	}{
+		{"vim", 12, []string{"vim", "+12", "plan.md"}},
+		{"/usr/bin/nvim", 3, []string{"/usr/bin/nvim", "+3", "plan.md"}},
+		{"code --wait", 7, []string{"code", "--wait", "--goto", "plan.md:7"}},
+		{"hx", 5, []string{"hx", "plan.md:5"}},
+		{"ed", 5, []string{"ed", "plan.md"}},
+		{"vim", 0, []string{"vim", "plan.md"}},
+	} {
+		if got := editorArgs(tc.editor, "plan.md", tc.line); !slices.Equal(got, tc.want) {
+			t.Errorf("editorArgs(%q, %d) = %q, want %q", tc.editor, tc.line, got, tc.want)
+		}
+	}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano -w")
+	if got := editorCommand("spec.md", 0).Args; !slices.Equal(got, []string{"nano", "-w", "spec.md"}) {
+		t.Errorf("editorCommand args = %q", got)
+	}
+}
+
+func TestOpenInEditor_ReloadsTrack(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel(base)
+	m.AllTracks = conductor.DiscoverTracks(base)
+	idx := slices.IndexFunc(m.Tracks(), func(t conductor.Track) bool { return t.TrackID == "feature-alpha_20260101" })
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenTasks, TrackIdx: idx, Cursor: 1})
+
+	if target, line, ok := m.editorTarget(); !ok || target != idx || line != 6 {
+		t.Errorf("editorTarget = %d, %d, %v; want the second task's line", target, line, ok)
+	}
+	result, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("P should open the editor")
+	}
+
+	// The editor checks off the second task and exits.
+	planPath := filepath.Join(base, "conductor", "tracks", "feature-alpha_20260101", "plan.md")
+	plan, _ := os.ReadFile(planPath)
+	if err := os.WriteFile(planPath, []byte(strings.Replace(string(plan), "- [ ] Task: Add", "- [x] Task: Add", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	result, cmd = m.Update(EditorClosedMsg{Track: m.Tracks()[idx], Err: errors.New("exit status 1")})
+	m = result.(Model)
+	if !strings.Contains(m.ViewTasks(), "Editor: exit status 1") {
+		t.Error("the editor error should be shown")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+	if task := m.Tracks()[idx].Phases[0].Tasks[1]; !task.Completed {
+		t.Errorf("task after reload = %+v", task)
+	}
+
+	m.Branch = "main"
+	if _, cmd := m.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")}); cmd != nil {
+		t.Error("tracks from another branch should not open in the editor")
+	}
+}
+
+func TestPlanEditor(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	planPath := filepath.Join(base, "conductor", "tracks", "feature-alpha_20260101", "plan.md")
+	original, _ := os.ReadFile(planPath)
+	m := NewModel(base)
+	m.AllTracks = conductor.DiscoverTracks(base)
+	idx := slices.IndexFunc(m.Tracks(), func(t conductor.Track) bool { return t.TrackID == "feature-alpha_20260101" })
+	m.Stack = append(m.Stack, Screen{ScreenType: ScreenPhases, TrackIdx: idx})
+	press := func(keys ...string) {
+		for _, k := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+			switch k {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			}
+			result, _ := m.HandleKey(msg)
+			m = result.(Model)
+		}
+	}
+	outline := func() string {
+		data, _ := os.ReadFile(planPath)
+		var parts []string
+		for _, ph := range conductor.ParsePlan(string(data)) {
+			s := fmt.Sprintf("%d %s:", ph.Number, ph.Name)
+			for _, task := range ph.Tasks {
+				s += " " + task.Name
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, " | ")
+	}
+
+	// A new phase below the cursor, then a task added to it.
+	press("n")
+	if !strings.Contains(m.ViewPhases(), "New phase:") {
+		t.Error("n should open the name input")
+	}
+	press("Release", "enter", "a", "Tag it", "enter")
+	if got := outline(); got != "1 Setup: Initialize project Add dependencies | 2 Release: Tag it" {
+		t.Fatalf("after adding = %q", got)
+	}
+	if m.CurrentScreen().Cursor != 1 || len(m.Tracks()[idx].Phases) != 2 {
+		t.Errorf("cursor = %d, phases = %d", m.CurrentScreen().Cursor, len(m.Tracks()[idx].Phases))
+	}
+
+	// Reorder, move to the next phase and rename on the tasks screen.
+	m.Stack = append(m.Stack[:2], Screen{ScreenType: ScreenTasks, TrackIdx: idx, PhaseIdx: 0})
+	press("J")
+	if got := outline(); got != "1 Setup: Add dependencies Initialize project | 2 Release: Tag it" {
+		t.Errorf("after J = %q", got)
+	}
+	press(">")
+	if got := outline(); got != "1 Setup: Add dependencies | 2 Release: Tag it Initialize project" {
+		t.Errorf("after > = %q", got)
+	}
+	if s := m.CurrentScreen(); s.PhaseIdx != 1 || s.Cursor != 1 {
+		t.Errorf("the cursor should follow the task: phase %d, cursor %d", s.PhaseIdx, s.Cursor)
+	}
+	press("e", " repo", "enter")
+	if got := outline(); got != "1 Setup: Add dependencies | 2 Release: Tag it Initialize project repo" {
+		t.Errorf("after rename = %q", got)
+	}
+	if data, _ := os.ReadFile(planPath); !strings.Contains(string(data), "- [x] Task: Initialize project repo `abc1234`") {
+		t.Errorf("rename should keep the checkbox and commit:\n%s", data)
+	}
+
+	// Delete asks first.
+	press("d")
+	if !strings.Contains(m.ViewTasks(), `Delete task "Initialize project repo" and its sub-tasks? [y/n]`) {
+		t.Errorf("missing delete prompt:\n%s", m.ViewTasks())
+	}
+	press("n")
+	if strings.Count(outline(), "Initialize") != 1 {
+		t.Error("declining should keep the task")
+	}
+	press("d", "y")
+	if got := outline(); got != "1 Setup: Add dependencies | 2 Release: Tag it" {
+		t.Errorf("after delete = %q", got)
+	}
+
+	// Every edit can be undone, newest first. Undoing the new phase
+	// leaves its tasks screen.
+	for range 6 {
+		press("u")
+	}
+	if data, _ := os.ReadFile(planPath); string(data) != string(original) {
+		t.Errorf("undo should restore the plan:\n%s", data)
+	}
+	if s := m.CurrentScreen(); s.ScreenType != ScreenPhases {
+		t.Errorf("screen = %d, want the phases list", s.ScreenType)
+	}
+	press("u")
+	if !strings.Contains(m.ViewPhases(), "Plan: nothing to undo") {
+		t.Error("undo past the first edit should say so")
+	}
+
+	// An empty name is rejected and the input stays open.
+	m.Stack = m.Stack[:2]
+	press("n", "enter")
+	if !m.CurrentScreen().Editing || m.PlanErr == nil {
+		t.Error("an empty name should keep the input open with an error")
+	}
+	press("esc")
+
+	m.Branch = "main"
+	press("n")
+	if m.CurrentScreen().Editing {
+		t.Error("plans read from another branch should not be editable")
+	}
+}
@@ -3,10 +3,13 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/history"
 	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/util"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/pkg/conductor"
 )
 
 // View renders the current screen.
@@ -28,6 +31,22 @@ func (m Model) View() string {
 		return m.ViewDetail()
 	case ScreenEdit:
 		return m.ViewEdit()
+	case ScreenHistory:
+		return m.ViewHistory()
+	case ScreenBranches:
+		return m.ViewBranches()
+	case ScreenRevert:
+		return m.ViewRevert()
+	case ScreenExport:
+		return m.ViewExport()
+	case ScreenLog:
+		return m.ViewLog()
+	case ScreenNewTrack:
+		return m.ViewNewTrack()
+	case ScreenArchive:
+		return m.ViewArchive()
+	case ScreenRename:
+		return m.ViewRename()
 	}
 	return ""
 }
@@ -57,10 +76,28 @@ func (m Model) ViewTracks() string {
 	tracks := m.Tracks()
 	s := m.CurrentScreen()
 
+	var breadcrumbs []string
+	if m.Branch != "" {
+		breadcrumbs = []string{"@" + m.Branch}
+	} else if m.MergeWorktrees {
+		breadcrumbs = []string{"All worktrees"}
+	}
+	if m.StatusFilter != "" {
+		breadcrumbs = append(breadcrumbs, "status: "+m.StatusFilter)
+	}
+	if m.TypeFilter != "" {
+		breadcrumbs = append(breadcrumbs, "type: "+m.TypeFilter)
+	}
+
 	var b strings.Builder
-	b.WriteString(m.RenderHeader(nil, "[q] Quit"))
+	b.WriteString(m.RenderHeader(breadcrumbs, "[q] Quit"))
 
 	if len(tracks) == 0 {
+		if m.StatusFilter != "" || m.TypeFilter != "" {
+			b.WriteString(" " + DimStyle.Render("No tracks match the filter.") + "\n")
+			b.WriteString(m.RenderFooter("[s] Status filter  [t] Type filter  [q] Quit"))
+			return b.String()
+		}
 		b.WriteString(" " + DimStyle.Render("No tracks found.") + "\n")
 		footer := fmt.Sprintf("[q] Quit")
 		b.WriteString(m.RenderFooter(footer))
@@ -74,13 +111,18 @@ func (m Model) ViewTracks() string {
 
 	vp := util.CalcViewport(len(tracks), s.Cursor, maxVis)
 
-	descW := m.Width - 64
+	descW := m.Width - 84
 	if descW < 8 {
 		descW = 8
 	}
 
+	branchHeader := "Branch"
+	if m.MergeWorktrees {
+		branchHeader = "Worktree"
+	}
+
 	// Column headers
-	b.WriteString(DimStyle.Render("  "+util.Pad("Track ID", 28)+util.Pad("Type", 10)+util.Pad("Status", 14)+util.Pad("Phases", 8)+"Description") + "\n")
+	b.WriteString(DimStyle.Render("  "+util.Pad("Track ID", 28)+util.Pad("Type", 10)+util.Pad("Status", 14)+util.Pad("Phases", 8)+util.Pad(branchHeader, 20)+"Description") + "\n")
 
 	if vp.MoreAbove > 0 {
 		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↑ %d more above", vp.MoreAbove)) + "\n")
@@ -102,14 +144,15 @@ func (m Model) ViewTracks() string {
 		}
 
 		statusStr := t.Status + tag
-		statusRendered := ColorStyle(util.StatusColor(t.Status)).Render(util.Pad(statusStr, 14))
+		statusRendered := ColorStyle(m.Config.Color(t.Status)).Render(util.Pad(statusStr, 14))
 
 		line := prefix +
 			util.Pad(util.Trunc(t.TrackID, 26), 28) +
 			util.Pad(t.Type, 10) +
 			statusRendered +
 			util.Pad(fmt.Sprintf("%d", len(t.Phases)), 8) +
-			util.Trunc(t.Description, descW)
+			util.Pad(m.branchLabel(t.TrackID), 20) +
+			util.Trunc(strings.Join(strings.Fields(t.Description), " "), descW)
 
 		if sel {
 			line = BoldStyle.Render(line)
@@ -125,11 +168,87 @@ func (m Model) ViewTracks() string {
 	if m.ShowArchived {
 		archiveHint = "Hide"
 	}
-	footer := fmt.Sprintf("[Enter] Phases  [e] Edit  [a] %s archived  [q] Quit", archiveHint)
+	editHint := "[n] New  [e] Edit  [R] Rename  [A] Archive  [r] Revert  [P/S/M] Open file  "
+	if m.Branch != "" {
+		editHint = ""
+	}
+	worktreeHint := "All"
+	if m.MergeWorktrees {
+		worktreeHint = "This"
+	}
+	hookHint := ""
+	if m.ConfigErr != nil || (m.Hooks != nil && len(m.Hooks.Commands) > 0) || (m.Webhooks != nil && m.Webhooks.Enabled()) {
+		hookHint = "[l] Hook log  "
+	}
+	footer := fmt.Sprintf("[Enter] Phases  %s[x] Export  [s/t] Filter  [a] %s archived  [b] Branch  [w] %s worktrees  %s[q] Quit", editHint, archiveHint, worktreeHint, hookHint)
 	b.WriteString(m.RenderFooter(footer))
 	return b.String()
 }
 
+// branchLabel formats a track's most advanced branch with its progress,
+// e.g. "feature/x 3/5", or "—" when branch data is unavailable. In the
+// merged worktree view it names the worktree the track was taken from.
+func (m Model) branchLabel(trackID string) string {
+	if m.MergeWorktrees {
+		origin, ok := m.TrackOrigins[trackID]
+		if !ok {
+			return "—"
+		}
+		return util.Trunc(origin.Worktree.Name(), 18)
+	}
+	p, ok := m.BranchProgress[trackID]
+	if !ok {
+		return "—"
+	}
+	progress := fmt.Sprintf(" %d/%d", p.Done, p.Total)
+	return util.Trunc(p.Branch, 18-len(progress)) + progress
+}
+
+// ViewBranches renders the branch selector listing the working tree and
+// every local and remote-tracking ref.
+func (m Model) ViewBranches() string {
+	s := m.CurrentScreen()
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{"Branches"}, "[Esc] Back"))
+	b.WriteString(" " + DimStyle.Render("Read tracks from another branch without checking it out.") + "\n")
+
+	items := append([]string{"(working tree)"}, m.Branches...)
+
+	maxVis := m.Height - 6
+	if maxVis < 1 {
+		maxVis = 1
+	}
+	vp := util.CalcViewport(len(items), s.Cursor, maxVis)
+
+	if vp.MoreAbove > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↑ %d more above", vp.MoreAbove)) + "\n")
+	}
+	for i, name := range items[vp.Start:vp.End] {
+		idx := vp.Start + i
+		prefix := "  "
+		if idx == s.Cursor {
+			prefix = CursorStyle.Render("> ")
+		}
+		active := (idx == 0 && m.Branch == "") || (idx > 0 && name == m.Branch)
+		marker := "  "
+		if active {
+			marker = ColorStyle("green").Render("* ")
+		}
+		line := prefix + marker + name
+		if idx == s.Cursor {
+			line = BoldStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if vp.MoreBelow > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↓ %d more below", vp.MoreBelow)) + "\n")
+	}
+
+	b.WriteString(m.RenderFooter("[↑↓] Navigate  [Enter] Select  [Esc] Back"))
+	return b.String()
+}
+
 // ViewPhases renders the phases list for a selected track.
 func (m Model) ViewPhases() string {
 	tracks := m.Tracks()
@@ -144,7 +263,7 @@ func (m Model) ViewPhases() string {
 	b.WriteString(m.RenderHeader([]string{track.TrackID}, "[Esc] Back"))
 	b.WriteString(" " + DimStyle.Render(util.Wrap(track.Description, m.Width-2, " ")) + "\n")
 
-	maxVis := m.Height - 7
+	maxVis := m.Height - 8
 	if maxVis < 1 {
 		maxVis = 1
 	}
@@ -168,14 +287,14 @@ func (m Model) ViewPhases() string {
 				done++
 			}
 		}
-		st := util.PhaseStatus(p)
+		st := conductor.PhaseStatus(p)
 
 		prefix := "  "
 		if sel {
 			prefix = CursorStyle.Render("> ")
 		}
 
-		statusRendered := ColorStyle(util.StatusColor(st)).Render(st)
+		statusRendered := ColorStyle(m.Config.Color(st)).Render(st)
 
 		line := prefix +
 			util.Pad(fmt.Sprintf("%d", p.Number), 4) +
@@ -193,7 +312,7 @@ func (m Model) ViewPhases() string {
 		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↓ %d more below", vp.MoreBelow)) + "\n")
 	}
 
-	b.WriteString(m.RenderFooter("[↑↓] Navigate  [Enter] View tasks  [Esc] Back"))
+	b.WriteString(m.renderPlanFooter("[↑↓] Navigate  [Enter] View tasks  [h] History  [r] Revert  " + m.openHint() + "[Esc] Back"))
 	return b.String()
 }
 
@@ -215,14 +334,18 @@ func (m Model) ViewTasks() string {
 	))
 	b.WriteString(" " + DimStyle.Render(util.Wrap(phase.Name, m.Width-2, " ")) + "\n")
 
-	maxVis := m.Height - 7
+	maxVis := m.Height - 8
 	if maxVis < 1 {
 		maxVis = 1
 	}
 
 	vp := util.CalcViewport(len(phase.Tasks), s.Cursor, maxVis)
 
-	b.WriteString(DimStyle.Render("  "+util.Pad("#", 4)+util.Pad("Task", 36)+util.Pad("Subs", 8)+util.Pad("Status", 10)+"Commit") + "\n")
+	lastCol := "Commit"
+	if m.ShowBlame {
+		lastCol = "Last changed by"
+	}
+	b.WriteString(DimStyle.Render("  "+util.Pad("#", 4)+util.Pad("Task", 36)+util.Pad("Subs", 8)+util.Pad("Status", 10)+lastCol) + "\n")
 
 	if vp.MoreAbove > 0 {
 		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↑ %d more above", vp.MoreAbove)) + "\n")
@@ -242,6 +365,9 @@ func (m Model) ViewTasks() string {
 		if t.Commit != "" {
 			commit = t.Commit
 		}
+		if m.ShowBlame {
+			commit = m.blameLabel(t.Line)
+		}
 
 		doneSubs := 0
 		for _, sub := range t.SubTasks {
@@ -255,7 +381,7 @@ func (m Model) ViewTasks() string {
 			prefix = CursorStyle.Render("> ")
 		}
 
-		statusRendered := ColorStyle(util.StatusColor(st)).Render(util.Pad(st, 10))
+		statusRendered := ColorStyle(m.Config.Color(st)).Render(util.Pad(st, 10))
 
 		line := prefix +
 			util.Pad(fmt.Sprintf("%d", idx+1), 4) +
@@ -274,10 +400,68 @@ func (m Model) ViewTasks() string {
 		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↓ %d more below", vp.MoreBelow)) + "\n")
 	}
 
-	b.WriteString(m.RenderFooter("[↑↓] Navigate  [Enter] View detail  [Esc] Back"))
+	if m.ShowBlame && m.BlameErr != nil {
+		b.WriteString(" " + ColorStyle("red").Render("Blame: "+m.BlameErr.Error()) + "\n")
+	}
+	b.WriteString(m.renderPlanFooter(fmt.Sprintf("[↑↓] Navigate  [Enter] View detail  [b] %s blame  [r] Revert  %s[Esc] Back", blameHint(m.ShowBlame), m.openHint())))
+	return b.String()
+}
+
+// openHint is the footer hint for the keys opening a track file in the
+// external editor, empty when tracks are read from another branch.
+func (m Model) openHint() string {
+	if m.Branch != "" {
+		return ""
+	}
+	return "[P/S/M] Open file  "
+}
+
+// renderPlanFooter renders the footer of ScreenPhases, ScreenTasks and
+// ScreenDetail: the name input or delete prompt of a plan edit while one
+// is open, otherwise the plan editing keys above footer.
+func (m Model) renderPlanFooter(footer string) string {
+	s := m.CurrentScreen()
+	var b strings.Builder
+	switch {
+	case m.Branch != "":
+	case s.Editing:
+		label := map[int]string{PlanAddPhase: "New phase:", PlanAddTask: "New task:", PlanAddSubTask: "New sub-task:", PlanRename: "Rename:"}[m.PlanAction]
+		b.WriteString(CursorStyle.Render("> ") + BoldStyle.Render(util.Pad(label, 14)) + m.PlanInput + CursorStyle.Render("_") + "\n")
+		footer = "[Enter] Save  [Esc] Cancel"
+	case s.Confirming:
+		b.WriteString(" " + BoldStyle.Render(m.planDeletePrompt()+" ") + DimStyle.Render("[y/n]") + "\n")
+		footer = "[y] Delete  [n] Cancel"
+	default:
+		hints := map[int]string{
+			ScreenPhases: "[n] New phase  [a] Add task  [e] Rename  [d] Delete  [u] Undo",
+			ScreenTasks:  "[n] New task  [a] Add sub-task  [e] Rename  [K/J] Move  [</>] Move to phase  [d] Delete  [u] Undo",
+			ScreenDetail: "[n] New sub-task  [e] Rename  [d] Delete  [u] Undo",
+		}[s.ScreenType]
+		b.WriteString(" " + DimStyle.Render(hints) + "\n")
+	}
+	if m.PlanErr != nil {
+		b.WriteString(" " + ColorStyle("red").Render("Plan: "+m.PlanErr.Error()) + "\n")
+	}
+	b.WriteString(m.RenderFooter(footer))
 	return b.String()
 }
 
+// planDeletePrompt asks whether to delete the plan item under the cursor.
+func (m Model) planDeletePrompt() string {
+	s := m.CurrentScreen()
+	name, _ := m.selectedPlanItem()
+	switch s.ScreenType {
+	case ScreenPhases:
+		return fmt.Sprintf("Delete phase %d %q and its tasks?", m.Tracks()[s.TrackIdx].Phases[s.Cursor].Number, name)
+	case ScreenTasks:
+		return fmt.Sprintf("Delete task %q and its sub-tasks?", name)
+	}
+	return fmt.Sprintf("Delete sub-task %q?", name)
+}
+
+// minEditLabelWidth is the narrowest label column on ScreenEdit.
+const minEditLabelWidth = 13
+
 // ViewEdit renders the edit screen for a selected track.
 func (m Model) ViewEdit() string {
 	tracks := m.Tracks()
@@ -290,36 +474,209 @@ func (m Model) ViewEdit() string {
 
 	var b strings.Builder
 	b.WriteString(m.RenderHeader([]string{track.TrackID, "Edit"}, "[Esc] Back"))
-	b.WriteString(" " + DimStyle.Render(util.Wrap(track.Description, m.Width-2, " ")) + "\n\n")
+	b.WriteString("\n")
+
+	fields := m.EditFields()
+	labelWidth := m.editLabelWidth()
+	indent := util.Spaces(labelWidth + 2)
+	for i, f := range fields {
+		prefix := "  "
+		selected := i == s.EditFieldIdx
+		if selected {
+			prefix = CursorStyle.Render("> ")
+		}
+
+		label := BoldStyle.Render(util.Pad(f.Name()+":", labelWidth))
+		raw := track.Value(f.Key)
+		text := f.Format(raw)
+		var value string
+		switch {
+		case selected && s.Editing && f.Kind != conductor.KindEnum:
+			value = m.FieldInput.View(indent)
+			if m.FieldErr != nil {
+				value += "\n" + indent + ColorStyle("red").Render(m.FieldErr.Error())
+			}
+		case text == "":
+			value = DimStyle.Render("(none)")
+		case f.Kind == conductor.KindEnum:
+			value = ColorStyle(m.Config.Color(text)).Render(text)
+		default:
+			// Wrap every row to the same width as the text area does.
+			lines := strings.Split(text, "\n")
+			for j, line := range lines {
+				lines[j] = util.Wrap(line, m.descWidth(), "")
+			}
+			value = strings.ReplaceAll(strings.Join(lines, "\n"), "\n", "\n"+indent)
+		}
+		if f.Kind == conductor.KindEnum && selected && s.Editing {
+			value = "[< " + value + " >]"
+		}
+		if !(selected && s.Editing) {
+			if err := f.Validate(raw); err != nil {
+				value += "  " + ColorStyle("red").Render(err.Error())
+			}
+		}
+
+		b.WriteString(prefix + label + value + "\n")
+	}
+
+	var lifecycle []string
+	for _, ts := range []struct {
+		label string
+		at    time.Time
+	}{
+		{"Created", track.CreatedAt}, {"Started", track.StartedAt},
+		{"Completed", track.CompletedAt}, {"Cancelled", track.CancelledAt},
+	} {
+		if !ts.at.IsZero() {
+			lifecycle = append(lifecycle, ts.label+" "+ts.at.Local().Format("2006-01-02 15:04"))
+		}
+	}
+	if len(lifecycle) > 0 {
+		b.WriteString("\n  " + DimStyle.Render(strings.Join(lifecycle, "  ")) + "\n")
+	}
+
+	if s.Confirming {
+		b.WriteString("\n " + BoldStyle.Render(fmt.Sprintf("Move status back from %s to %s? ", track.Status, m.PendingStatus)) +
+			DimStyle.Render("[y/n]") + "\n")
+		return b.String()
+	}
+
+	f := m.editField()
+	switch {
+	case s.Editing && f.Multiline:
+		b.WriteString(m.RenderFooter("[Ctrl+S] Save  [Enter] New line  [Arrows/Home/End] Move  [Esc] Discard"))
+	case s.Editing && f.Kind != conductor.KindEnum:
+		b.WriteString(m.RenderFooter("[Enter] Save  [Left/Right/Home/End] Move  [Esc] Discard"))
+	case s.Editing:
+		b.WriteString(m.RenderFooter("[Left/Right] Change value  [Enter] Save  [Up/Down] Select field  [Esc] Stop editing"))
+	default:
+		b.WriteString(m.RenderFooter("[Up/Down] Select field  [Enter] Edit  [P/S/M] Open file  [Esc] Back"))
+	}
+	return b.String()
+}
+
+// editLabelWidth is the width of the label column on ScreenEdit: wide
+// enough for the longest field name.
+func (m Model) editLabelWidth() int {
+	width := minEditLabelWidth
+	for _, f := range m.EditFields() {
+		width = max(width, len(f.Name())+2)
+	}
+	return width
+}
+
+// ViewNewTrack renders the new-track form.
+func (m Model) ViewNewTrack() string {
+	s := m.CurrentScreen()
+	form := m.NewTrack
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{"New track"}, "[Esc] Cancel"))
 
 	fields := []struct {
 		label string
 		value string
 	}{
-		{"Status", track.Status},
-		{"Type", track.Type},
+		{"Name", form.ShortName},
+		{"Type", form.Type},
+		{"Description", form.Description},
 	}
-
 	for i, f := range fields {
 		prefix := "  "
+		value := f.value
 		if i == s.EditFieldIdx {
 			prefix = CursorStyle.Render("> ")
+			if i == NewTrackFieldType {
+				value = "[< " + value + " >]"
+			} else {
+				value += CursorStyle.Render("_")
+			}
 		}
+		label := BoldStyle.Render(util.Pad(f.label+":", 14))
+		b.WriteString(prefix + label + util.Wrap(value, m.Width-16, util.Spaces(16)) + "\n")
+	}
+	b.WriteString("\n")
 
-		label := BoldStyle.Render(util.Pad(f.label+":", 10))
-		value := ColorStyle(util.StatusColor(f.value)).Render(f.value)
-		if i == s.EditFieldIdx && s.Editing {
-			value = "[< " + value + " >]"
-		}
+	id := "shortname_" + time.Now().Format("20060102")
+	if name := strings.TrimSpace(form.ShortName); name != "" {
+		id = conductor.TrackID(name, time.Now())
+	}
+	b.WriteString(" " + DimStyle.Render("Track ID: "+id) + "\n")
+	b.WriteString(" " + DimStyle.Render("Creates metadata.json, index.md, spec.md and plan.md and adds the track to conductor/tracks.md.") + "\n")
+	if m.NewTrackErr != nil {
+		b.WriteString("\n " + ColorStyle("red").Render("Error: "+m.NewTrackErr.Error()) + "\n")
+	}
 
-		b.WriteString(prefix + label + value + "\n")
+	b.WriteString(m.RenderFooter("[Tab/↑↓] Field  [←→] Type  [Enter] Create  [Esc] Cancel"))
+	return b.String()
+}
+
+// ViewArchive renders the confirmation for archiving or unarchiving a
+// track, listing every file that will move.
+func (m Model) ViewArchive() string {
+	tracks := m.Tracks()
+	s := m.CurrentScreen()
+	if s.TrackIdx >= len(tracks) {
+		return ""
 	}
+	t := tracks[s.TrackIdx]
 
-	if s.Editing {
-		b.WriteString(m.RenderFooter("[Left/Right] Change value  [Enter] Save  [Up/Down] Select field  [Esc] Stop editing"))
-	} else {
-		b.WriteString(m.RenderFooter("[Up/Down] Select field  [Enter] Edit  [Esc] Back"))
+	action, from, to, registry := "Archive", "tracks", "archive", "removes its entry from"
+	if t.Source == "archived" {
+		action, from, to, registry = "Unarchive", "archive", "tracks", "adds an entry to"
 	}
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{t.TrackID, action}, "[Esc] Cancel"))
+	b.WriteString(fmt.Sprintf(" Moves %d files from %s to %s and %s conductor/tracks.md:\n\n",
+		len(m.ArchiveFiles), BoldStyle.Render("conductor/"+from+"/"+t.TrackID+"/"), BoldStyle.Render("conductor/"+to+"/"+t.TrackID+"/"), registry))
+
+	maxVis := m.Height - 12
+	if maxVis < 1 {
+		maxVis = 1
+	}
+	vp := util.CalcViewport(len(m.ArchiveFiles), s.Cursor, maxVis)
+	if vp.MoreAbove > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↑ %d more above", vp.MoreAbove)) + "\n")
+	}
+	for _, f := range m.ArchiveFiles[vp.Start:vp.End] {
+		b.WriteString("   " + f + "\n")
+	}
+	if vp.MoreBelow > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↓ %d more below", vp.MoreBelow)) + "\n")
+	}
+
+	status := "keep " + t.Status
+	if m.ArchiveStatus != "" {
+		status = ColorStyle(m.Config.Color(m.ArchiveStatus)).Render(m.ArchiveStatus)
+	}
+	b.WriteString("\n " + BoldStyle.Render("Status: ") + "[< " + status + " >]\n")
+	if m.ArchiveErr != nil {
+		b.WriteString("\n " + ColorStyle("red").Render("Error: "+m.ArchiveErr.Error()) + "\n")
+	}
+
+	b.WriteString(m.RenderFooter(fmt.Sprintf("[y] %s  [←→] Status  [↑↓] Scroll  [Esc] Cancel", action)))
+	return b.String()
+}
+
+// ViewRename renders the prompt for a track's new ID.
+func (m Model) ViewRename() string {
+	tracks := m.Tracks()
+	s := m.CurrentScreen()
+	if s.TrackIdx >= len(tracks) {
+		return ""
+	}
+	t := tracks[s.TrackIdx]
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{t.TrackID, "Rename"}, "[Esc] Cancel"))
+	b.WriteString(CursorStyle.Render("> ") + BoldStyle.Render(util.Pad("New ID:", 10)) + m.RenameID + CursorStyle.Render("_") + "\n\n")
+	b.WriteString(" " + DimStyle.Render("Renames the track directory and updates track_id in metadata.json, the conductor/tracks.md link and index.md references.") + "\n")
+	if m.RenameErr != nil {
+		b.WriteString("\n " + ColorStyle("red").Render("Error: "+m.RenameErr.Error()) + "\n")
+	}
+	b.WriteString(m.RenderFooter("[Enter] Rename  [Esc] Cancel"))
 	return b.String()
 }
 
@@ -354,18 +711,25 @@ func (m Model) ViewDetail() string {
 
 	b.WriteString(" " + BoldStyle.Render("Task: ") + util.Wrap(task.Name, m.Width-8, "        ") + "\n")
 
-	statusLine := " Status: " + ColorStyle(util.StatusColor(st)).Render(st)
+	statusLine := " Status: " + ColorStyle(m.Config.Color(st)).Render(st)
 	if task.Commit != "" {
 		statusLine += "          Commit: " + BoldStyle.Render(task.Commit)
 	}
-	b.WriteString(statusLine + "\n\n")
+	b.WriteString(statusLine + "\n")
+	if m.ShowBlame {
+		b.WriteString(" Last changed by: " + m.blameLabel(task.Line) + "\n")
+		if m.BlameErr != nil {
+			b.WriteString(" " + ColorStyle("red").Render("Blame: "+m.BlameErr.Error()) + "\n")
+		}
+	}
+	b.WriteString("\n")
 
 	if len(task.SubTasks) == 0 {
 		b.WriteString(" " + DimStyle.Render("No sub-tasks.") + "\n")
 	} else {
 		b.WriteString(" " + BoldStyle.Render(fmt.Sprintf("Sub-tasks: (%d)", len(task.SubTasks))) + "\n")
 
-		maxSub := m.Height - 10
+		maxSub := m.Height - 11
 		if maxSub < 1 {
 			maxSub = 1
 		}
@@ -388,7 +752,11 @@ func (m Model) ViewDetail() string {
 			if idx == s.Cursor {
 				prefix = CursorStyle.Render("> ")
 			}
-			b.WriteString("  " + prefix + check + " " + util.Wrap(sub.Name, m.Width-11, "            ") + "\n")
+			line := "  " + prefix + check + " " + util.Wrap(sub.Name, m.Width-11, "            ")
+			if m.ShowBlame {
+				line += "  " + DimStyle.Render(m.blameLabel(sub.Line))
+			}
+			b.WriteString(line + "\n")
 		}
 
 		if vp.MoreBelow > 0 {
@@ -396,10 +764,331 @@ func (m Model) ViewDetail() string {
 		}
 	}
 
-	footerText := "[Esc] Back"
+	footerText := fmt.Sprintf("[b] %s blame  %s[Esc] Back", blameHint(m.ShowBlame), m.openHint())
 	if len(task.SubTasks) > 0 {
-		footerText = "[↑↓] Navigate  [Esc] Back"
+		footerText = "[↑↓] Navigate  " + footerText
+	}
+	b.WriteString(m.renderPlanFooter(footerText))
+	return b.String()
+}
+
+// ViewHistory renders the burndown chart, weekly throughput and per-task
+// cycle times derived from the track's plan.md git history.
+func (m Model) ViewHistory() string {
+	tracks := m.Tracks()
+	s := m.CurrentScreen()
+
+	if s.TrackIdx >= len(tracks) {
+		return ""
+	}
+	track := tracks[s.TrackIdx]
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{track.TrackID, "History"}, "[Esc] Back"))
+
+	if m.HistoryErr != nil {
+		b.WriteString(" " + ColorStyle("red").Render("Error: "+m.HistoryErr.Error()) + "\n")
+		b.WriteString(m.RenderFooter("[Esc] Back"))
+		return b.String()
+	}
+	if m.HistoryTrack != track.TrackID {
+		b.WriteString(" " + DimStyle.Render("Reading git history...") + "\n")
+		b.WriteString(m.RenderFooter("[Esc] Back"))
+		return b.String()
+	}
+
+	chartH := m.Height / 4
+	if chartH < 3 {
+		chartH = 3
+	}
+	b.WriteString(" " + BoldStyle.Render("Burndown (remaining tasks)") + "\n")
+	for _, line := range strings.Split(history.RenderBurndown(m.History.Burndown, m.Width-4, chartH), "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString("\n " + BoldStyle.Render("Throughput") + "\n")
+	for _, line := range strings.Split(history.RenderThroughput(m.History.Throughput), "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+
+	cycles := m.History.Cycles
+	b.WriteString("\n " + BoldStyle.Render("Cycle time") + "\n")
+	b.WriteString(DimStyle.Render("  "+util.Pad("Ph", 4)+util.Pad("Task", 36)+util.Pad("Started", 12)+util.Pad("Done", 12)+"Cycle") + "\n")
+
+	maxVis := m.Height - chartH - 14 - len(m.History.Throughput)
+	if maxVis < 1 {
+		maxVis = 1
+	}
+	vp := util.CalcViewport(len(cycles), s.Cursor, maxVis)
+
+	if vp.MoreAbove > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↑ %d more above", vp.MoreAbove)) + "\n")
+	}
+	for i, c := range cycles[vp.Start:vp.End] {
+		idx := vp.Start + i
+		prefix := "  "
+		if idx == s.Cursor {
+			prefix = CursorStyle.Render("> ")
+		}
+		started, done, cycle := "—", "—", "—"
+		if !c.Started.IsZero() {
+			started = c.Started.Format("2006-01-02")
+		}
+		if !c.Done.IsZero() {
+			done = c.Done.Format("2006-01-02")
+		}
+		if d := c.CycleTime(); d > 0 {
+			cycle = history.FormatDuration(d)
+		}
+		line := prefix +
+			util.Pad(fmt.Sprintf("%d", c.Phase), 4) +
+			util.Pad(util.Trunc(c.Task, 34), 36) +
+			util.Pad(started, 12) +
+			util.Pad(done, 12) +
+			cycle
+		if idx == s.Cursor {
+			line = BoldStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
 	}
-	b.WriteString(m.RenderFooter(footerText))
+	if vp.MoreBelow > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↓ %d more below", vp.MoreBelow)) + "\n")
+	}
+
+	b.WriteString(m.RenderFooter("[↑↓] Navigate  [Esc] Back"))
 	return b.String()
 }
+
+// ViewRevert renders the revert plan for a track, phase or task. The plan
+// is a dry run until the user presses x and confirms.
+func (m Model) ViewRevert() string {
+	s := m.CurrentScreen()
+	target, ok := m.revertTarget(s)
+	if !ok {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{util.Trunc(target.Track.TrackID, 20), "Revert"}, "[Esc] Back"))
+	b.WriteString(" " + BoldStyle.Render("Target: ") + "Revert " + target.Describe() + "\n")
+
+	if !m.RevertLoaded {
+		b.WriteString(" " + DimStyle.Render("Investigating git history...") + "\n")
+		b.WriteString(m.RenderFooter("[Esc] Back"))
+		return b.String()
+	}
+
+	plan := m.RevertPlan
+	b.WriteString(" " + BoldStyle.Render(fmt.Sprintf("Commits to revert: %d", len(plan.Commits))) +
+		DimStyle.Render("  (newest first)") + "\n")
+
+	maxVis := m.Height - 9 - len(plan.Missing) - len(plan.Warnings)
+	if maxVis < 1 {
+		maxVis = 1
+	}
+	vp := util.CalcViewport(len(plan.Commits), s.Cursor, maxVis)
+	if vp.MoreAbove > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↑ %d more above", vp.MoreAbove)) + "\n")
+	}
+	for i, c := range plan.Commits[vp.Start:vp.End] {
+		idx := vp.Start + i
+		prefix := "  "
+		if idx == s.Cursor {
+			prefix = CursorStyle.Render("> ")
+		}
+		line := prefix +
+			BoldStyle.Render(c.SHA[:7]) + " " +
+			util.Pad(c.Time.Format("2006-01-02"), 11) +
+			util.Pad(c.Reason, 13) +
+			util.Trunc(c.Subject, m.Width-40)
+		b.WriteString(line + "\n")
+	}
+	if vp.MoreBelow > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↓ %d more below", vp.MoreBelow)) + "\n")
+	}
+	for _, sha := range plan.Missing {
+		b.WriteString(" " + ColorStyle("yellow").Render("Warning: commit "+sha+" from plan.md not found in git history") + "\n")
+	}
+	for _, w := range plan.Warnings {
+		b.WriteString(" " + ColorStyle("yellow").Render("Warning: "+w) + "\n")
+	}
+
+	switch {
+	case m.RevertErr != nil:
+		b.WriteString(" " + ColorStyle("red").Render("Error: "+m.RevertErr.Error()) + "\n")
+	case m.RevertRunning:
+		b.WriteString(" " + DimStyle.Render("Running git revert...") + "\n")
+	case m.RevertDone:
+		b.WriteString(" " + ColorStyle("green").Render(fmt.Sprintf("Reverted %d commits.", len(plan.Commits))) + "\n")
+	case len(plan.Commits) == 0:
+		b.WriteString(" " + DimStyle.Render("Nothing to revert.") + "\n")
+	}
+
+	if s.Confirming {
+		b.WriteString(" " + BoldStyle.Render(fmt.Sprintf("Run git revert on %d commits? ", len(plan.Commits))) +
+			DimStyle.Render("[y/n]") + "\n")
+		return b.String()
+	}
+	if !m.RevertDone && !m.RevertRunning && len(plan.Commits) > 0 {
+		b.WriteString(m.RenderFooter("[↑↓] Navigate  [x] Execute revert  [Esc] Back"))
+	} else {
+		b.WriteString(m.RenderFooter("[Esc] Back"))
+	}
+	return b.String()
+}
+
+// ViewExport renders the report export menu for the listed tracks or the
+// track that was under the cursor.
+func (m Model) ViewExport() string {
+	tracks := m.Tracks()
+	s := m.CurrentScreen()
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{"Export"}, "[Esc] Back"))
+
+	scope := fmt.Sprintf("all listed tracks (%d)", len(tracks))
+	if !m.ExportAll && s.TrackIdx < len(tracks) {
+		scope = tracks[s.TrackIdx].TrackID
+	}
+	b.WriteString(" " + BoldStyle.Render("Tracks: ") + scope + "\n\n")
+
+	for i, opt := range ExportOptions {
+		prefix := "  "
+		if i == s.Cursor {
+			prefix = CursorStyle.Render("> ")
+		}
+		line := prefix + opt.Label
+		if i == s.Cursor {
+			line = BoldStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n")
+
+	switch {
+	case m.ExportErr != nil:
+		b.WriteString(" " + ColorStyle("red").Render("Error: "+m.ExportErr.Error()) + "\n")
+	case m.ExportPath != "":
+		b.WriteString(" " + ColorStyle("green").Render("Wrote "+m.ExportPath) + "\n")
+	}
+
+	b.WriteString(m.RenderFooter("[↑↓] Navigate  [Enter] Export  [t] Toggle selected/all tracks  [Esc] Back"))
+	return b.String()
+}
+
+// webhookStatus summarises the webhook outbox for the log panel.
+func (m Model) webhookStatus() string {
+	r := m.WebhookResult
+	line := DimStyle.Render(fmt.Sprintf("Webhooks: %d endpoints, %d pending", len(m.Webhooks.Endpoints), r.Pending))
+	switch {
+	case m.WebhookErr != nil:
+		line += "  " + ColorStyle("red").Render("queue error: "+m.WebhookErr.Error())
+	case r.LastError != "":
+		line += "  " + ColorStyle("red").Render(util.Trunc("last error: "+r.LastError, m.Width-40))
+	}
+	return line
+}
+
+// ViewLog renders the hook log panel: recent hook runs, newest first,
+// with the output of the selected run below.
+func (m Model) ViewLog() string {
+	s := m.CurrentScreen()
+
+	var b strings.Builder
+	b.WriteString(m.RenderHeader([]string{"Hook log"}, "[Esc] Back"))
+	if m.ConfigErr != nil {
+		b.WriteString(" " + ColorStyle("red").Render("Config error: "+m.ConfigErr.Error()) + "\n")
+	}
+	if m.HooksRunning > 0 {
+		b.WriteString(" " + DimStyle.Render(fmt.Sprintf("%d hooks running...", m.HooksRunning)) + "\n")
+	}
+	if m.Webhooks != nil && m.Webhooks.Enabled() {
+		b.WriteString(" " + m.webhookStatus() + "\n")
+	}
+	if len(m.HookLog) == 0 {
+		hooks := 0
+		if m.Hooks != nil {
+			hooks = len(m.Hooks.Commands)
+		}
+		b.WriteString(" " + DimStyle.Render(fmt.Sprintf("No hooks have run yet (%d configured in conductor/tui.json).", hooks)) + "\n")
+		b.WriteString(m.RenderFooter("[Esc] Back"))
+		return b.String()
+	}
+
+	listH := (m.Height - 8) / 2
+	if listH < 1 {
+		listH = 1
+	}
+	vp := util.CalcViewport(len(m.HookLog), s.Cursor, listH)
+	if vp.MoreAbove > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↑ %d more above", vp.MoreAbove)) + "\n")
+	}
+	for i, r := range m.HookLog[vp.Start:vp.End] {
+		idx := vp.Start + i
+		prefix := "  "
+		if idx == s.Cursor {
+			prefix = CursorStyle.Render("> ")
+		}
+		status := ColorStyle("green").Render(util.Pad("exit 0", 10))
+		switch {
+		case r.Err != nil:
+			status = ColorStyle("red").Render(util.Pad("error", 10))
+		case r.ExitCode != 0:
+			status = ColorStyle("red").Render(util.Pad(fmt.Sprintf("exit %d", r.ExitCode), 10))
+		}
+		line := prefix + util.Pad(r.Started.Format("15:04:05"), 10) + status +
+			util.Pad(util.Trunc(r.Event.Type, 20), 22) +
+			util.Pad(util.Trunc(r.Event.TrackID, 24), 26) +
+			util.Trunc(r.Hook.Run, m.Width-92)
+		if idx == s.Cursor {
+			line = BoldStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	if vp.MoreBelow > 0 {
+		b.WriteString(DimStyle.Render(fmt.Sprintf("  ↓ %d more below", vp.MoreBelow)) + "\n")
+	}
+
+	if s.Cursor < len(m.HookLog) {
+		r := m.HookLog[s.Cursor]
+		b.WriteString("\n " + BoldStyle.Render("$ "+r.Hook.Run) +
+			DimStyle.Render(fmt.Sprintf("  (%s)", r.Duration.Round(time.Millisecond))) + "\n")
+		if r.Err != nil {
+			b.WriteString(" " + ColorStyle("red").Render(r.Err.Error()) + "\n")
+		}
+		out := strings.Split(strings.TrimRight(r.Output, "\n"), "\n")
+		outH := m.Height - 8 - listH
+		if outH < 1 {
+			outH = 1
+		}
+		if len(out) > outH {
+			out = out[len(out)-outH:]
+		}
+		for _, l := range out {
+			b.WriteString(" " + DimStyle.Render(util.Trunc(l, m.Width-2)) + "\n")
+		}
+	}
+
+	b.WriteString(m.RenderFooter("[↑↓] Navigate  [Esc] Back"))
+	return b.String()
+}
+
+// blameLabel formats who last changed a plan.md line, e.g.
+// "abc1234 Alice 2026-03-02", for the blame overlay.
+func (m Model) blameLabel(line int) string {
+	bl, ok := m.BlameFor(line)
+	if !ok {
+		return "…"
+	}
+	if bl.Uncommitted() {
+		return "uncommitted"
+	}
+	return bl.Commit[:7] + " " + util.Trunc(bl.Author, 16) + " " + bl.AuthorTime.Format("2006-01-02")
+}
+
+// blameHint returns the footer verb for toggling the blame overlay.
+func blameHint(shown bool) string {
+	if shown {
+		return "Hide"
+	}
+	return "Show"
+}
@@ -1,6 +1,7 @@
 package util
 
-import "github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/data"
+// Colors lists the colour names StatusColor returns.
+var Colors = []string{"green", "yellow", "cyan", "magenta", "blue", "red", "gray"}
 
 // StatusColor returns a color name for the given status string.
 func StatusColor(s string) string {
@@ -23,23 +24,3 @@ func StatusColor(s string) string {
 		return ""
 	}
 }
-
-// PhaseStatus derives a status string from a Phase's task completion.
-func PhaseStatus(p data.Phase) string {
-	if len(p.Tasks) == 0 {
-		return "empty"
-	}
-	done := 0
-	for _, t := range p.Tasks {
-		if t.Completed {
-			done++
-		}
-	}
-	if done == len(p.Tasks) {
-		return "completed"
-	}
-	if done > 0 {
-		return "in_progress"
-	}
-	return "pending"
-}
@@ -1,6 +1,8 @@
 // Package util provides string helpers and status utilities for the Conductor TUI.
 package util
 
+import "strings"
+
 // Trunc truncates s to max characters, adding "..." if truncated.
 func Trunc(s string, max int) string {
 	if len(s) <= max {
@@ -73,6 +75,18 @@ func Wrap(s string, width int, indent string) string {
 	return string(result)
 }
 
+// SplitList splits a comma-separated list such as a flag or query value,
+// trimming spaces and dropping empty entries.
+func SplitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 // splitWords splits a string by whitespace into words.
 func splitWords(s string) []string {
 	var words []string
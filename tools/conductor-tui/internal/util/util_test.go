@@ -2,9 +2,8 @@ package util
 
 import (
 	"fmt"
+	"slices"
 	"testing"
-
-	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/data"
 )
 
 func TestTrunc(t *testing.T) {
@@ -70,6 +69,26 @@ func TestWrap(t *testing.T) {
 	}
 }
 
+func TestSplitList(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"new", []string{"new"}},
+		{"new,in_progress", []string{"new", "in_progress"}},
+		{" new , in_progress ", []string{"new", "in_progress"}},
+		{"new,,blocked,", []string{"new", "blocked"}},
+		{" , ", nil},
+	}
+	for _, tt := range tests {
+		got := SplitList(tt.input)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SplitList(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
 // --- CalcViewport Tests ---
 
 func TestCalcViewport_ZeroItems(t *testing.T) {
@@ -305,47 +324,3 @@ func TestStatusColor(t *testing.T) {
 		}
 	}
 }
-
-func TestPhaseStatus(t *testing.T) {
-	tests := []struct {
-		name  string
-		phase data.Phase
-		want  string
-	}{
-		{
-			name:  "empty phase",
-			phase: data.Phase{Tasks: []data.Task{}},
-			want:  "empty",
-		},
-		{
-			name: "all completed",
-			phase: data.Phase{Tasks: []data.Task{
-				{Completed: true},
-				{Completed: true},
-			}},
-			want: "completed",
-		},
-		{
-			name: "some completed",
-			phase: data.Phase{Tasks: []data.Task{
-				{Completed: true},
-				{Completed: false},
-			}},
-			want: "in_progress",
-		},
-		{
-			name: "none completed",
-			phase: data.Phase{Tasks: []data.Task{
-				{Completed: false},
-				{Completed: false},
-			}},
-			want: "pending",
-		},
-	}
-	for _, tt := range tests {
-		got := PhaseStatus(tt.phase)
-		if got != tt.want {
-			t.Errorf("PhaseStatus(%s) = %q, want %q", tt.name, got, tt.want)
-		}
-	}
-}
@@ -0,0 +1,321 @@
+// Package webhook delivers change events to HTTP endpoints through a
+// persistent on-disk outbox, with HMAC signing and exponential backoff.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+)
+
+// Request headers set on every delivery.
+const (
+	HeaderEvent     = "X-Conductor-Event"
+	HeaderDelivery  = "X-Conductor-Delivery"
+	HeaderSignature = "X-Conductor-Signature"
+)
+
+// Delivery is one event queued for one endpoint. It is stored as a JSON
+// file in the outbox until delivered or out of attempts.
+type Delivery struct {
+	ID          string       `json:"id"`
+	URL         string       `json:"url"`
+	Endpoint    int          `json:"endpoint"` // index in Endpoints when queued
+	Event       events.Event `json:"event"`
+	Created     time.Time    `json:"created"`
+	Attempts    int          `json:"attempts"`
+	NextAttempt time.Time    `json:"next_attempt"`
+	LastError   string       `json:"last_error,omitempty"`
+}
+
+// Result reports the outcome of one Flush.
+type Result struct {
+	Delivered int
+	Retrying  int
+	Failed    int // moved to the failed directory this flush
+	Pending   int // still queued afterwards
+	LastError string
+}
+
+// Outbox queues deliveries in Dir and sends them. It is safe for
+// concurrent use; overlapping flushes are skipped.
+type Outbox struct {
+	Dir       string
+	Endpoints []config.Endpoint
+	Client    *http.Client
+
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	// now is replaced in tests.
+	now      func() time.Time
+	seq      atomic.Uint64
+	flushing sync.Mutex
+}
+
+// New returns the outbox configured for the project at basePath.
+func New(basePath string, cfg config.Webhooks) *Outbox {
+	dir := cfg.Outbox
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(basePath, filepath.FromSlash(dir))
+	}
+	return &Outbox{
+		Dir:            dir,
+		Endpoints:      cfg.Endpoints,
+		Client:         &http.Client{Timeout: time.Duration(cfg.Timeout)},
+		maxAttempts:    cfg.MaxAttempts,
+		initialBackoff: time.Duration(cfg.InitialBackoff),
+		maxBackoff:     time.Duration(cfg.MaxBackoff),
+		now:            time.Now,
+	}
+}
+
+// Enabled reports whether any endpoint is configured.
+func (o *Outbox) Enabled() bool {
+	return len(o.Endpoints) > 0
+}
+
+// Enqueue writes a delivery for every endpoint that wants each event.
+func (o *Outbox) Enqueue(evs []events.Event) error {
+	if !o.Enabled() || len(evs) == 0 {
+		return nil
+	}
+	if err := o.ensureDir(); err != nil {
+		return err
+	}
+	now := o.now().UTC()
+	for _, e := range evs {
+		for i, ep := range o.Endpoints {
+			if !ep.Wants(e.Type) {
+				continue
+			}
+			d := Delivery{
+				ID:          fmt.Sprintf("%d-%d", now.UnixNano(), o.seq.Add(1)),
+				URL:         ep.URL,
+				Endpoint:    i,
+				Event:       e,
+				Created:     now,
+				NextAttempt: now,
+			}
+			if err := o.save(d); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Flush attempts every delivery that is due, oldest first. Successful
+// deliveries are removed; failures are rescheduled with backoff or, after
+// the last attempt, moved to Dir/failed. If another flush is running it
+// returns immediately with ok false.
+func (o *Outbox) Flush(ctx context.Context) (res Result, ok bool) {
+	if !o.flushing.TryLock() {
+		return res, false
+	}
+	defer o.flushing.Unlock()
+
+	queued, err := o.Pending()
+	if err != nil {
+		res.LastError = err.Error()
+		return res, true
+	}
+	for _, d := range queued {
+		if ctx.Err() != nil || o.now().Before(d.NextAttempt) {
+			res.Pending++
+			continue
+		}
+		err := o.send(ctx, d)
+		if err == nil {
+			res.Delivered++
+			os.Remove(o.path(d.ID))
+			continue
+		}
+		d.Attempts++
+		d.LastError = err.Error()
+		res.LastError = fmt.Sprintf("%s: %s", d.URL, err)
+		if d.Attempts >= o.maxAttempts {
+			res.Failed++
+			o.fail(d)
+			continue
+		}
+		d.NextAttempt = o.now().UTC().Add(o.backoff(d.Attempts))
+		o.save(d)
+		res.Retrying++
+		res.Pending++
+	}
+	return res, true
+}
+
+// Run flushes the outbox every interval until ctx is cancelled.
+func (o *Outbox) Run(ctx context.Context, interval time.Duration, report func(Result)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if res, ok := o.Flush(ctx); ok && report != nil {
+			report(res)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Pending returns the queued deliveries, oldest first.
+func (o *Outbox) Pending() ([]Delivery, error) {
+	entries, err := os.ReadDir(o.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []Delivery
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(o.Dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var d Delivery
+		if json.Unmarshal(raw, &d) == nil {
+			out = append(out, d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Created.Before(out[j].Created) || (out[i].Created.Equal(out[j].Created) && out[i].ID < out[j].ID)
+	})
+	return out, nil
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.initialBackoff
+	for i := 1; i < attempts && d < o.maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, o.maxBackoff)
+}
+
+// send posts d with the secret and headers of the endpoint it was queued
+// for. Several endpoints may share a URL, so that is the endpoint at the
+// same index, provided it still has the same URL.
+func (o *Outbox) send(ctx context.Context, d Delivery) error {
+	if d.Endpoint < 0 || d.Endpoint >= len(o.Endpoints) || o.Endpoints[d.Endpoint].URL != d.URL {
+		return fmt.Errorf("endpoint no longer configured")
+	}
+	ep := o.Endpoints[d.Endpoint]
+
+	body, err := json.Marshal(d.Event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	// Custom headers go first so that they cannot replace the signature.
+	for k, v := range ep.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "conductor-tui")
+	req.Header.Set(HeaderEvent, d.Event.Type)
+	req.Header.Set(HeaderDelivery, d.ID)
+	if secret := ep.SigningSecret(); secret != "" {
+		req.Header.Set(HeaderSignature, Sign(secret, body))
+	}
+
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by
+// the hex HMAC-SHA256 of body keyed with secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is valid for body, for receivers.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+func (o *Outbox) path(id string) string {
+	return filepath.Join(o.Dir, id+".json")
+}
+
+// ensureDir creates the outbox and keeps it out of version control.
+func (o *Outbox) ensureDir() error {
+	if err := os.MkdirAll(o.Dir, 0o755); err != nil {
+		return err
+	}
+	ignore := filepath.Join(o.Dir, ".gitignore")
+	if _, err := os.Stat(ignore); !os.IsNotExist(err) {
+		return err
+	}
+	return os.WriteFile(ignore, []byte("*\n"), 0o644)
+}
+
+// save writes d atomically so a crash never leaves a torn delivery.
+func (o *Outbox) save(d Delivery) error {
+	raw, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(o.Dir, ".delivery-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), o.path(d.ID))
+}
+
+// fail moves a delivery that ran out of attempts to Dir/failed.
+func (o *Outbox) fail(d Delivery) {
+	failed := filepath.Join(o.Dir, "failed")
+	if err := os.MkdirAll(failed, 0o755); err != nil {
+		return
+	}
+	if err := o.save(d); err != nil {
+		return
+	}
+	os.Rename(o.path(d.ID), filepath.Join(failed, d.ID+".json"))
+}
@@ -0,0 +1,248 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/config"
+	"github.com/cloudaura-io/cloudaura-marketplace/tools/conductor-tui/internal/events"
+)
+
+var completed = events.Event{
+	Type: events.TaskCompleted, TrackID: "auth_20260301", Phase: 2, PhaseName: "Build",
+	Task: "Add login", Commit: "abc1234", Time: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+}
+
+// receiver is an httptest endpoint that records requests and answers with
+// the queued status codes, then 200.
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.bodies = append(r.bodies, body)
+		r.headers = append(r.headers, req.Header.Clone())
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+// newOutbox returns an outbox in a temp project whose clock is controlled
+// by the returned pointer.
+func newOutbox(t *testing.T, base string, maxAttempts int, endpoints ...config.Endpoint) (*Outbox, *time.Time) {
+	t.Helper()
+	o := New(base, config.Webhooks{
+		Endpoints:      endpoints,
+		Outbox:         config.DefaultOutbox,
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: config.Duration(time.Second),
+		MaxBackoff:     config.Duration(4 * time.Second),
+		Timeout:        config.Duration(5 * time.Second),
+	})
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	o.now = func() time.Time { return now }
+	return o, &now
+}
+
+func TestFlush_SignedDelivery(t *testing.T) {
+	rcv := newReceiver(t)
+	o, _ := newOutbox(t, t.TempDir(), 3, config.Endpoint{
+		URL: rcv.URL, Secret: "s3cret", Headers: map[string]string{"Authorization": "Bearer tok"},
+	})
+	if err := o.Enqueue([]events.Event{completed}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	res, ok := o.Flush(context.Background())
+	if !ok || res.Delivered != 1 || res.Pending != 0 {
+		t.Fatalf("Flush = %+v, %v", res, ok)
+	}
+
+	h, body := rcv.headers[0], rcv.bodies[0]
+	if !Verify("s3cret", body, h.Get(HeaderSignature)) {
+		t.Errorf("signature %q does not verify", h.Get(HeaderSignature))
+	}
+	if h.Get(HeaderEvent) != events.TaskCompleted || h.Get(HeaderDelivery) == "" {
+		t.Errorf("headers = %v", h)
+	}
+	if h.Get("Content-Type") != "application/json" || h.Get("Authorization") != "Bearer tok" {
+		t.Errorf("headers = %v", h)
+	}
+	var got events.Event
+	if err := json.Unmarshal(body, &got); err != nil || got.TrackID != completed.TrackID || got.Commit != "abc1234" {
+		t.Errorf("body = %s (%v)", body, err)
+	}
+	if pending, _ := o.Pending(); len(pending) != 0 {
+		t.Errorf("pending after delivery = %+v", pending)
+	}
+}
+
+func TestFlush_EndpointsSharingURL(t *testing.T) {
+	rcv := newReceiver(t)
+	o, _ := newOutbox(t, t.TempDir(), 3,
+		config.Endpoint{URL: rcv.URL, Secret: "first"},
+		config.Endpoint{URL: rcv.URL, Secret: "second", Headers: map[string]string{HeaderSignature: "sha256=forged", HeaderEvent: "spoofed"}},
+	)
+	if err := o.Enqueue([]events.Event{completed}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if res, _ := o.Flush(context.Background()); res.Delivered != 2 {
+		t.Fatalf("Flush = %+v", res)
+	}
+
+	verified := map[string]bool{}
+	for i, h := range rcv.headers {
+		for _, secret := range []string{"first", "second"} {
+			if Verify(secret, rcv.bodies[i], h.Get(HeaderSignature)) {
+				verified[secret] = true
+			}
+		}
+		if h.Get(HeaderEvent) != events.TaskCompleted {
+			t.Errorf("%s = %q, custom headers should not replace it", HeaderEvent, h.Get(HeaderEvent))
+		}
+	}
+	if !verified["first"] || !verified["second"] {
+		t.Errorf("each delivery should be signed with its own endpoint's secret, verified %v", verified)
+	}
+}
+
+func TestEnqueue_FiltersByEventType(t *testing.T) {
+	rcv := newReceiver(t)
+	o, _ := newOutbox(t, t.TempDir(), 3,
+		config.Endpoint{URL: rcv.URL + "/all"},
+		config.Endpoint{URL: rcv.URL + "/started", Events: []string{events.TaskStarted}},
+	)
+	if err := o.Enqueue([]events.Event{completed}); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	pending, _ := o.Pending()
+	if len(pending) != 1 || pending[0].URL != rcv.URL+"/all" {
+		t.Errorf("pending = %+v", pending)
+	}
+	if h := rcv.headers; len(h) != 0 {
+		t.Error("Enqueue should not send")
+	}
+}
+
+func TestFlush_RetriesWithBackoff(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway)
+	o, now := newOutbox(t, t.TempDir(), 5, config.Endpoint{URL: rcv.URL})
+	o.Enqueue([]events.Event{completed})
+
+	res, _ := o.Flush(context.Background())
+	if res.Retrying != 1 || res.Pending != 1 || res.LastError == "" {
+		t.Fatalf("first flush = %+v", res)
+	}
+	pending, _ := o.Pending()
+	if pending[0].Attempts != 1 || !pending[0].NextAttempt.Equal(now.Add(time.Second)) || pending[0].LastError != "HTTP 500" {
+		t.Fatalf("after first failure = %+v", pending[0])
+	}
+
+	// Not due yet: nothing is sent.
+	o.Flush(context.Background())
+	if rcv.count() != 1 {
+		t.Fatalf("sent %d requests before the backoff elapsed", rcv.count())
+	}
+
+	*now = now.Add(time.Second)
+	o.Flush(context.Background())
+	pending, _ = o.Pending()
+	if pending[0].Attempts != 2 || !pending[0].NextAttempt.Equal(now.Add(2*time.Second)) {
+		t.Fatalf("after second failure = %+v", pending[0])
+	}
+
+	*now = now.Add(2 * time.Second)
+	res, _ = o.Flush(context.Background())
+	if res.Delivered != 1 || res.Pending != 0 || rcv.count() != 3 {
+		t.Errorf("third flush = %+v after %d requests", res, rcv.count())
+	}
+}
+
+func TestOutbox_PersistsAcrossRestarts(t *testing.T) {
+	base := t.TempDir()
+	rcv := newReceiver(t)
+	ep := config.Endpoint{URL: rcv.URL}
+
+	first, _ := newOutbox(t, base, 3, ep)
+	first.Enqueue([]events.Event{completed})
+
+	second, _ := newOutbox(t, base, 3, ep)
+	res, _ := second.Flush(context.Background())
+	if res.Delivered != 1 || rcv.count() != 1 {
+		t.Errorf("flush after restart = %+v", res)
+	}
+	if _, err := os.Stat(filepath.Join(base, ".conductor-tui", "outbox", ".gitignore")); err != nil {
+		t.Errorf("outbox should be git-ignored: %v", err)
+	}
+}
+
+func TestFlush_MovesExhaustedToFailed(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError, http.StatusInternalServerError)
+	o, now := newOutbox(t, t.TempDir(), 2, config.Endpoint{URL: rcv.URL})
+	o.Enqueue([]events.Event{completed})
+
+	o.Flush(context.Background())
+	*now = now.Add(time.Minute)
+	res, _ := o.Flush(context.Background())
+	if res.Failed != 1 || res.Pending != 0 {
+		t.Fatalf("flush = %+v", res)
+	}
+	failed, _ := filepath.Glob(filepath.Join(o.Dir, "failed", "*.json"))
+	if len(failed) != 1 {
+		t.Fatalf("failed deliveries = %v", failed)
+	}
+	raw, _ := os.ReadFile(failed[0])
+	var d Delivery
+	if err := json.Unmarshal(raw, &d); err != nil || d.Attempts != 2 || d.Event.Task != "Add login" {
+		t.Errorf("failed delivery = %+v (%v)", d, err)
+	}
+}
+
+func TestFlush_RemovedEndpointFails(t *testing.T) {
+	base := t.TempDir()
+	old, _ := newOutbox(t, base, 1, config.Endpoint{URL: "http://old.invalid/hook"})
+	old.Enqueue([]events.Event{completed})
+
+	o, _ := newOutbox(t, base, 1, config.Endpoint{URL: "http://new.invalid/hook"})
+	res, _ := o.Flush(context.Background())
+	if res.Failed != 1 {
+		t.Errorf("flush = %+v", res)
+	}
+}
+
+func TestBackoff_Capped(t *testing.T) {
+	o, _ := newOutbox(t, t.TempDir(), 10)
+	for attempts, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 8: 4 * time.Second} {
+		if got := o.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
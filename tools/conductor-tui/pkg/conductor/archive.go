@@ -0,0 +1,116 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// TrackFiles lists the files of t relative to its directory, sorted.
+// They are what Archive and Unarchive move.
+func (p Project) TrackFiles(t Track) ([]string, error) {
+	dir := p.Dir(t)
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// Archive moves an active track to conductor/archive/<id> and removes its
+// section from conductor/tracks.md, as /conductor:review does once a track
+// is done. A non-empty status is written to metadata.json as well. The
+// directory is moved with a single rename; if a later step fails the move
+// is undone.
+func (p Project) Archive(t Track, status string) (Track, error) {
+	if t.Source == "archived" {
+		return t, fmt.Errorf("track %q is already archived", t.TrackID)
+	}
+	return p.move(t, "archived", status, func(moved Track) error {
+		err := RemoveRegistryEntry(p.RegistryPath(), moved.TrackID)
+		if errors.Is(err, ErrNotRegistered) || errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	})
+}
+
+// Unarchive moves an archived track back to conductor/tracks/<id> and
+// appends a section for it to conductor/tracks.md. A non-empty status is
+// written to metadata.json as well. The move is undone if a later step
+// fails.
+func (p Project) Unarchive(t Track, status string) (Track, error) {
+	if t.Source != "archived" {
+		return t, fmt.Errorf("track %q is not archived", t.TrackID)
+	}
+	return p.move(t, "active", status, func(moved Track) error {
+		entries, err := p.Registry()
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if _, ok := findEntryIn(entries, moved.TrackID); ok {
+			return SetRegistryStatus(p.RegistryPath(), moved.TrackID, moved.Status)
+		}
+		return AddRegistryEntry(p.RegistryPath(), RegistryEntry{TrackID: moved.TrackID, Description: moved.Description, Status: moved.Status})
+	})
+}
+
+// move renames t's directory to the one for source, then saves the new
+// status and runs register, renaming back if either fails.
+func (p Project) move(t Track, source, status string, register func(Track) error) (Track, error) {
+	from := p.Dir(t)
+	moved := t
+	moved.Source = source
+	if status != "" {
+		if err := p.Workflow.SetStatus(&moved, status, time.Now()); err != nil {
+			return t, err
+		}
+	}
+	to := p.Dir(moved)
+	if _, err := os.Stat(to); err == nil {
+		return t, fmt.Errorf("%s already exists", to)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return t, err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return t, err
+	}
+
+	undo := func(err error) (Track, error) {
+		if rerr := os.Rename(to, from); rerr != nil {
+			return t, fmt.Errorf("%w (moving it back also failed: %v)", err, rerr)
+		}
+		return t, err
+	}
+	if moved.Status != t.Status {
+		if err := SaveMetadata(filepath.Join(to, "metadata.json"), moved); err != nil {
+			return undo(err)
+		}
+	}
+	if err := register(moved); err != nil {
+		if moved.Status != t.Status {
+			// Put back the original metadata before moving it back.
+			if serr := SaveMetadata(filepath.Join(to, "metadata.json"), t); serr != nil {
+				return undo(fmt.Errorf("%w (restoring metadata.json also failed: %v)", err, serr))
+			}
+		}
+		return undo(err)
+	}
+	return moved, nil
+}
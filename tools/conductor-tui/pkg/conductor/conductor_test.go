@@ -0,0 +1,1402 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadMetadata_Valid(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/valid_metadata.json")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	track, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+
+	if track.TrackID != "feature-auth_20260101" {
+		t.Errorf("TrackID = %q, want %q", track.TrackID, "feature-auth_20260101")
+	}
+	if track.Type != "feature" {
+		t.Errorf("Type = %q, want %q", track.Type, "feature")
+	}
+	if track.Status != "in_progress" {
+		t.Errorf("Status = %q, want %q", track.Status, "in_progress")
+	}
+	if track.Description != "Add authentication system" {
+		t.Errorf("Description = %q, want %q", track.Description, "Add authentication system")
+	}
+}
+
+func TestLoadMetadata_FallbackValues(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/partial_metadata.json")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	track, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+
+	if track.TrackID != "bugfix-login_20260105" {
+		t.Errorf("TrackID = %q, want %q", track.TrackID, "bugfix-login_20260105")
+	}
+	if track.Type != "unknown" {
+		t.Errorf("Type = %q, want %q (fallback)", track.Type, "unknown")
+	}
+	if track.Status != "unknown" {
+		t.Errorf("Status = %q, want %q (fallback)", track.Status, "unknown")
+	}
+	if track.Description != "" {
+		t.Errorf("Description = %q, want %q (fallback)", track.Description, "")
+	}
+}
+
+func TestLoadMetadata_InvalidJSON(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/invalid_metadata.json")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	_, err = LoadMetadata(data)
+	if err == nil {
+		t.Error("expected error for invalid JSON, got nil")
+	}
+}
+
+func TestLoadMetadata_FallbackTrackID(t *testing.T) {
+	// When track_id is empty, LoadMetadata should still succeed;
+	// the caller (DiscoverTracks) handles fallback to directory name.
+	data := []byte(`{"type": "bug"}`)
+	track, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+	if track.TrackID != "" {
+		t.Errorf("TrackID = %q, want empty string", track.TrackID)
+	}
+	if track.Type != "bug" {
+		t.Errorf("Type = %q, want %q", track.Type, "bug")
+	}
+}
+
+func TestLoadMetadata_CreatedAt(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/valid_metadata.json")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	track, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+
+	expected := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	if !track.CreatedAt.Equal(expected) {
+		t.Errorf("CreatedAt = %v, want %v", track.CreatedAt, expected)
+	}
+
+	expectedUpdated := time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC)
+	if !track.UpdatedAt.Equal(expectedUpdated) {
+		t.Errorf("UpdatedAt = %v, want %v", track.UpdatedAt, expectedUpdated)
+	}
+}
+
+func TestLoadMetadata_MissingCreatedAt(t *testing.T) {
+	data := []byte(`{"track_id": "test", "type": "bug"}`)
+	track, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+
+	if !track.CreatedAt.IsZero() {
+		t.Errorf("CreatedAt should be zero value when missing, got %v", track.CreatedAt)
+	}
+	if !track.UpdatedAt.IsZero() {
+		t.Errorf("UpdatedAt should be zero value when missing, got %v", track.UpdatedAt)
+	}
+}
+
+func TestSaveMetadata_WritesFile(t *testing.T) {
+	dir := t.TempDir()
+	metaPath := filepath.Join(dir, "metadata.json")
+
+	track := Track{
+		TrackID:     "test-track",
+		Type:        "feature",
+		Status:      "in_progress",
+		Description: "Test track",
+		CreatedAt:   time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	err := SaveMetadata(metaPath, track)
+	if err != nil {
+		t.Fatalf("SaveMetadata returned error: %v", err)
+	}
+
+	// Read back and verify
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+
+	loaded, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata of written file returned error: %v", err)
+	}
+
+	if loaded.TrackID != "test-track" {
+		t.Errorf("TrackID = %q, want %q", loaded.TrackID, "test-track")
+	}
+	if loaded.Type != "feature" {
+		t.Errorf("Type = %q, want %q", loaded.Type, "feature")
+	}
+	if loaded.Status != "in_progress" {
+		t.Errorf("Status = %q, want %q", loaded.Status, "in_progress")
+	}
+	if loaded.Description != "Test track" {
+		t.Errorf("Description = %q, want %q", loaded.Description, "Test track")
+	}
+}
+
+func TestSaveMetadata_UpdatesTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	metaPath := filepath.Join(dir, "metadata.json")
+
+	track := Track{
+		TrackID:   "test-track",
+		Type:      "bug",
+		Status:    "new",
+		CreatedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	before := time.Now().UTC().Truncate(time.Second)
+	err := SaveMetadata(metaPath, track)
+	if err != nil {
+		t.Fatalf("SaveMetadata returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+
+	loaded, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+
+	// updated_at should be set to current time (truncated to seconds due to RFC3339)
+	if loaded.UpdatedAt.Before(before) {
+		t.Errorf("UpdatedAt = %v, expected >= %v", loaded.UpdatedAt, before)
+	}
+}
+
+func TestSaveMetadata_PreservesCreatedAt(t *testing.T) {
+	dir := t.TempDir()
+	metaPath := filepath.Join(dir, "metadata.json")
+
+	createdAt := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	track := Track{
+		TrackID:   "test-track",
+		Type:      "chore",
+		Status:    "completed",
+		CreatedAt: createdAt,
+	}
+
+	err := SaveMetadata(metaPath, track)
+	if err != nil {
+		t.Fatalf("SaveMetadata returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+
+	loaded, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+
+	if !loaded.CreatedAt.Equal(createdAt) {
+		t.Errorf("CreatedAt = %v, want %v (should be preserved)", loaded.CreatedAt, createdAt)
+	}
+}
+
+func TestSaveMetadata_AtomicWrite(t *testing.T) {
+	dir := t.TempDir()
+	metaPath := filepath.Join(dir, "metadata.json")
+
+	// Write initial content
+	track := Track{TrackID: "test", Type: "feature", Status: "new"}
+	err := SaveMetadata(metaPath, track)
+	if err != nil {
+		t.Fatalf("SaveMetadata returned error: %v", err)
+	}
+
+	// Verify file exists and is valid JSON
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	_, err = LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("written file is not valid metadata: %v", err)
+	}
+}
+
+// --- Plan Parsing Tests ---
+
+func TestSaveMetadata_PreservesUnknownFields(t *testing.T) {
+	metaPath := filepath.Join(t.TempDir(), "metadata.json")
+	data := []byte(`{"track_id": "a_20260101", "type": "feature", "status": "new", "priority": "high", "labels": ["ui", "api"], "extra": {"nested": [1, 2]}}`)
+	track, err := LoadMetadata(data)
+	if err != nil {
+		t.Fatalf("LoadMetadata returned error: %v", err)
+	}
+	track.Status = "in_progress"
+	if err := SaveMetadata(metaPath, track); err != nil {
+		t.Fatalf("SaveMetadata returned error: %v", err)
+	}
+
+	written, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(written)
+	for _, want := range []string{`"status": "in_progress"`, `"priority": "high"`, "\"labels\": [\n    \"ui\",", `"nested": [`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("metadata.json lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Index(got, `"extra"`) > strings.Index(got, `"labels"`) || strings.Index(got, `"updated_at"`) > strings.Index(got, `"extra"`) {
+		t.Errorf("unknown fields should follow the known ones, sorted:\n%s", got)
+	}
+}
+
+func TestField_ParseAndValidate(t *testing.T) {
+	priority := Field{Key: "priority", Label: "Priority", Kind: KindEnum, Options: []string{"low", "high"}}
+	for _, tc := range []struct {
+		field   Field
+		input   string
+		want    string
+		wantErr string
+	}{
+		{priority, "high", `"high"`, ""},
+		{priority, "urgent", "", "Priority must be one of low, high"},
+		{priority, " ", "", ""},
+		{Field{Key: "owner", Kind: KindText, Required: true}, "", "", "owner is required"},
+		{Field{Key: "target", Kind: KindDate}, "2026-11-01", `"2026-11-01"`, ""},
+		{Field{Key: "target", Kind: KindDate}, "next week", "", "target must be a date (YYYY-MM-DD)"},
+		{Field{Key: "estimate", Kind: KindNumber}, "2.50", "2.5", ""},
+		{Field{Key: "estimate", Kind: KindNumber}, "two", "", "estimate must be a number"},
+		{Field{Key: "labels", Kind: KindList}, "ui, api,, ", `["ui","api"]`, ""},
+	} {
+		raw, err := tc.field.Parse(tc.input)
+		if tc.wantErr != "" {
+			if err == nil || err.Error() != tc.wantErr {
+				t.Errorf("Parse(%q) error = %v, want %q", tc.input, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil || string(raw) != tc.want {
+			t.Errorf("Parse(%q) = %s, %v, want %s", tc.input, raw, err, tc.want)
+		}
+		if got := tc.field.Format(raw); tc.field.Kind == KindList && got != "ui, api" {
+			t.Errorf("Format(%s) = %q", raw, got)
+		}
+	}
+}
+
+func TestParsePlan_FullPlan(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/full_plan.md")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	phases := ParsePlan(string(data))
+
+	if len(phases) != 3 {
+		t.Fatalf("got %d phases, want 3", len(phases))
+	}
+
+	// Phase 1: with checkpoint
+	p1 := phases[0]
+	if p1.Number != 1 {
+		t.Errorf("Phase 1 Number = %d, want 1", p1.Number)
+	}
+	if p1.Name != "Setup" {
+		t.Errorf("Phase 1 Name = %q, want %q", p1.Name, "Setup")
+	}
+	if p1.Checkpoint != "abc1234" {
+		t.Errorf("Phase 1 Checkpoint = %q, want %q", p1.Checkpoint, "abc1234")
+	}
+	if len(p1.Tasks) != 2 {
+		t.Fatalf("Phase 1 has %d tasks, want 2", len(p1.Tasks))
+	}
+
+	// Phase 1, Task 1: completed with commit and sub-tasks
+	task1 := p1.Tasks[0]
+	if task1.Name != "Create project structure" {
+		t.Errorf("Task 1 Name = %q, want %q", task1.Name, "Create project structure")
+	}
+	if !task1.Completed {
+		t.Error("Task 1 should be completed")
+	}
+	if task1.Commit != "def5678" {
+		t.Errorf("Task 1 Commit = %q, want %q", task1.Commit, "def5678")
+	}
+	if len(task1.SubTasks) != 2 {
+		t.Fatalf("Task 1 has %d sub-tasks, want 2", len(task1.SubTasks))
+	}
+	if !task1.SubTasks[0].Completed {
+		t.Error("Task 1 SubTask 0 should be completed")
+	}
+	if task1.SubTasks[0].Name != "Create directory layout" {
+		t.Errorf("Task 1 SubTask 0 Name = %q, want %q", task1.SubTasks[0].Name, "Create directory layout")
+	}
+
+	// Phase 1, Task 2: incomplete without commit, mixed sub-tasks
+	task2 := p1.Tasks[1]
+	if task2.Name != "Add dependencies" {
+		t.Errorf("Task 2 Name = %q, want %q", task2.Name, "Add dependencies")
+	}
+	if task2.Completed {
+		t.Error("Task 2 should not be completed")
+	}
+	if task2.Commit != "" {
+		t.Errorf("Task 2 Commit = %q, want empty", task2.Commit)
+	}
+	if len(task2.SubTasks) != 2 {
+		t.Fatalf("Task 2 has %d sub-tasks, want 2", len(task2.SubTasks))
+	}
+	if task2.SubTasks[0].Completed {
+		t.Error("Task 2 SubTask 0 should not be completed")
+	}
+	if !task2.SubTasks[1].Completed {
+		t.Error("Task 2 SubTask 1 should be completed")
+	}
+
+	// Phase 2: without checkpoint
+	p2 := phases[1]
+	if p2.Number != 2 {
+		t.Errorf("Phase 2 Number = %d, want 2", p2.Number)
+	}
+	if p2.Name != "Implementation" {
+		t.Errorf("Phase 2 Name = %q, want %q", p2.Name, "Implementation")
+	}
+	if p2.Checkpoint != "" {
+		t.Errorf("Phase 2 Checkpoint = %q, want empty", p2.Checkpoint)
+	}
+	if len(p2.Tasks) != 2 {
+		t.Fatalf("Phase 2 has %d tasks, want 2", len(p2.Tasks))
+	}
+
+	// Phase 3: fully completed
+	p3 := phases[2]
+	if len(p3.Tasks) != 2 {
+		t.Fatalf("Phase 3 has %d tasks, want 2", len(p3.Tasks))
+	}
+	if !p3.Tasks[0].Completed || !p3.Tasks[1].Completed {
+		t.Error("Phase 3 tasks should all be completed")
+	}
+}
+
+func TestParsePlan_Empty(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/empty_plan.md")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	phases := ParsePlan(string(data))
+	if len(phases) != 0 {
+		t.Errorf("got %d phases, want 0", len(phases))
+	}
+}
+
+func TestParsePlan_NoPhases(t *testing.T) {
+	data, err := os.ReadFile("../../testdata/no_phases_plan.md")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+
+	phases := ParsePlan(string(data))
+	if len(phases) != 0 {
+		t.Errorf("got %d phases, want 0", len(phases))
+	}
+}
+
+func TestParsePlan_EmptyString(t *testing.T) {
+	phases := ParsePlan("")
+	if len(phases) != 0 {
+		t.Errorf("got %d phases, want 0", len(phases))
+	}
+}
+
+func TestParsePlan_PhaseWithoutCheckpoint(t *testing.T) {
+	content := "## Phase 5: Deployment\n\n- [x] Task: Deploy to production `abc1234`\n"
+	phases := ParsePlan(content)
+	if len(phases) != 1 {
+		t.Fatalf("got %d phases, want 1", len(phases))
+	}
+	if phases[0].Checkpoint != "" {
+		t.Errorf("Checkpoint = %q, want empty", phases[0].Checkpoint)
+	}
+	if phases[0].Number != 5 {
+		t.Errorf("Number = %d, want 5", phases[0].Number)
+	}
+}
+
+func TestParsePlan_TaskWithoutCommit(t *testing.T) {
+	content := "## Phase 1: Setup\n\n- [ ] Task: Initialize project\n"
+	phases := ParsePlan(content)
+	if len(phases) != 1 {
+		t.Fatalf("got %d phases, want 1", len(phases))
+	}
+	if len(phases[0].Tasks) != 1 {
+		t.Fatalf("got %d tasks, want 1", len(phases[0].Tasks))
+	}
+	task := phases[0].Tasks[0]
+	if task.Completed {
+		t.Error("task should not be completed")
+	}
+	if task.Commit != "" {
+		t.Errorf("Commit = %q, want empty", task.Commit)
+	}
+}
+
+// --- Track Discovery Tests ---
+
+func TestDiscoverTracks_AllTracks(t *testing.T) {
+	tracks := DiscoverTracks("../../testdata/discovery")
+
+	if len(tracks) != 3 {
+		t.Fatalf("got %d tracks, want 3", len(tracks))
+	}
+
+	// Active tracks should come first, sorted by creation date (newest first)
+	// bugfix-beta (2026-01-02) is newer than feature-alpha (2026-01-01)
+	if tracks[0].TrackID != "bugfix-beta_20260102" {
+		t.Errorf("tracks[0].TrackID = %q, want %q (newest active first)", tracks[0].TrackID, "bugfix-beta_20260102")
+	}
+	if tracks[0].Source != "active" {
+		t.Errorf("tracks[0].Source = %q, want %q", tracks[0].Source, "active")
+	}
+
+	if tracks[1].TrackID != "feature-alpha_20260101" {
+		t.Errorf("tracks[1].TrackID = %q, want %q", tracks[1].TrackID, "feature-alpha_20260101")
+	}
+	if tracks[1].Source != "active" {
+		t.Errorf("tracks[1].Source = %q, want %q", tracks[1].Source, "active")
+	}
+
+	// Archived track should be last
+	if tracks[2].TrackID != "feature-gamma_20250601" {
+		t.Errorf("tracks[2].TrackID = %q, want %q", tracks[2].TrackID, "feature-gamma_20250601")
+	}
+	if tracks[2].Source != "archived" {
+		t.Errorf("tracks[2].Source = %q, want %q", tracks[2].Source, "archived")
+	}
+}
+
+func TestLoadTrack(t *testing.T) {
+	track, err := LoadTrack("../../testdata/discovery/conductor/tracks/feature-alpha_20260101", "active")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if track.TrackID != "feature-alpha_20260101" || track.Source != "active" || len(track.Phases) != 1 {
+		t.Errorf("LoadTrack = %+v", track)
+	}
+	if _, err := LoadTrack(t.TempDir(), "active"); err == nil {
+		t.Error("a directory without metadata.json should fail")
+	}
+}
+
+func TestDiscoverTracks_ActiveWithPlan(t *testing.T) {
+	tracks := DiscoverTracks("../../testdata/discovery")
+
+	// feature-alpha has a plan.md with 1 phase and 2 tasks
+	var alpha Track
+	for _, tr := range tracks {
+		if tr.TrackID == "feature-alpha_20260101" {
+			alpha = tr
+			break
+		}
+	}
+	if len(alpha.Phases) != 1 {
+		t.Fatalf("alpha has %d phases, want 1", len(alpha.Phases))
+	}
+	if len(alpha.Phases[0].Tasks) != 2 {
+		t.Errorf("alpha phase 1 has %d tasks, want 2", len(alpha.Phases[0].Tasks))
+	}
+}
+
+func TestDiscoverTracks_MissingDirectory(t *testing.T) {
+	// Should handle missing base directory gracefully
+	tracks := DiscoverTracks("../../testdata/nonexistent")
+	if len(tracks) != 0 {
+		t.Errorf("got %d tracks, want 0 for missing directory", len(tracks))
+	}
+}
+
+func TestDiscoverTracks_SortOrder(t *testing.T) {
+	tracks := DiscoverTracks("../../testdata/discovery")
+
+	// Verify active tracks come before archived
+	foundArchived := false
+	for _, tr := range tracks {
+		if tr.Source == "archived" {
+			foundArchived = true
+		}
+		if foundArchived && tr.Source == "active" {
+			t.Error("found active track after archived track; sorting is broken")
+		}
+	}
+}
+
+func TestDiscoverTracks_FilterArchived(t *testing.T) {
+	allTracks := DiscoverTracks("../../testdata/discovery")
+
+	// Simulate filtering archived tracks (as done in TUI)
+	var active []Track
+	for _, tr := range allTracks {
+		if tr.Source != "archived" {
+			active = append(active, tr)
+		}
+	}
+
+	if len(active) != 2 {
+		t.Errorf("got %d active tracks, want 2", len(active))
+	}
+	for _, tr := range active {
+		if tr.Source == "archived" {
+			t.Error("found archived track after filtering")
+		}
+	}
+}
+
+func TestDiscoverTracks_SortByCreationDate(t *testing.T) {
+	tracks := DiscoverTracks("../../testdata/discovery")
+
+	// Active tracks should be sorted newest first
+	var activeTracks []Track
+	for _, tr := range tracks {
+		if tr.Source == "active" {
+			activeTracks = append(activeTracks, tr)
+		}
+	}
+
+	if len(activeTracks) < 2 {
+		t.Fatalf("expected at least 2 active tracks, got %d", len(activeTracks))
+	}
+
+	// bugfix-beta (2026-01-02) should come before feature-alpha (2026-01-01)
+	if activeTracks[0].TrackID != "bugfix-beta_20260102" {
+		t.Errorf("first active track = %q, want %q (newest first)", activeTracks[0].TrackID, "bugfix-beta_20260102")
+	}
+	if activeTracks[1].TrackID != "feature-alpha_20260101" {
+		t.Errorf("second active track = %q, want %q", activeTracks[1].TrackID, "feature-alpha_20260101")
+	}
+}
+
+func TestDiscoverTracks_MissingCreatedAtSortedToBottom(t *testing.T) {
+	// Tracks without created_at should sort to the bottom of their group.
+	// In the current testdata, all tracks have created_at, so this tests the
+	// sort logic via a unit test on the sort function directly.
+	tracks := []Track{
+		{TrackID: "has-date", Source: "active", CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{TrackID: "no-date", Source: "active"},
+		{TrackID: "newer-date", Source: "active", CreatedAt: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	sorted := SortTracks(tracks)
+
+	// newer-date first, then has-date, then no-date (zero time at bottom)
+	if sorted[0].TrackID != "newer-date" {
+		t.Errorf("sorted[0] = %q, want %q", sorted[0].TrackID, "newer-date")
+	}
+	if sorted[1].TrackID != "has-date" {
+		t.Errorf("sorted[1] = %q, want %q", sorted[1].TrackID, "has-date")
+	}
+	if sorted[2].TrackID != "no-date" {
+		t.Errorf("sorted[2] = %q, want %q", sorted[2].TrackID, "no-date")
+	}
+}
+
+// Placeholder to ensure testdata directory is accessible
+func TestTestdataDirectoryExists(t *testing.T) {
+	info, err := os.Stat("../../testdata")
+	if err != nil {
+		t.Fatalf("testdata directory not found: %v", err)
+	}
+	if !info.IsDir() {
+		t.Fatal("testdata is not a directory")
+	}
+
+	// Verify all expected test files exist
+	expectedFiles := []string{
+		"valid_metadata.json",
+		"partial_metadata.json",
+		"invalid_metadata.json",
+	}
+	for _, f := range expectedFiles {
+		if _, err := os.Stat(filepath.Join("../../testdata", f)); err != nil {
+			t.Errorf("expected file %q not found: %v", f, err)
+		}
+	}
+}
+
+func TestParsePlan_InProgressTask(t *testing.T) {
+	content := "## Phase 1: Work\n\n- [~] Task: Started work\n- [x] Task: Finished `abc1234`\n"
+	phases := ParsePlan(content)
+	if len(phases) != 1 || len(phases[0].Tasks) != 2 {
+		t.Fatalf("unexpected parse result: %+v", phases)
+	}
+	started := phases[0].Tasks[0]
+	if !started.InProgress || started.Completed {
+		t.Errorf("[~] task: InProgress=%v Completed=%v, want true/false", started.InProgress, started.Completed)
+	}
+	if phases[0].Tasks[1].InProgress {
+		t.Error("[x] task should not be InProgress")
+	}
+}
+
+func TestParsePlan_LineNumbers(t *testing.T) {
+	content := "# Plan\n\n## Phase 1: Work\n\n- [ ] Task: First\n    - [ ] Sub\n- [x] Task: Second\n"
+	phases := ParsePlan(content)
+	if len(phases) != 1 {
+		t.Fatalf("got %d phases, want 1", len(phases))
+	}
+	p := phases[0]
+	if p.Line != 3 {
+		t.Errorf("phase Line = %d, want 3", p.Line)
+	}
+	if p.Tasks[0].Line != 5 || p.Tasks[1].Line != 7 {
+		t.Errorf("task Lines = %d, %d; want 5, 7", p.Tasks[0].Line, p.Tasks[1].Line)
+	}
+	if p.Tasks[0].SubTasks[0].Line != 6 {
+		t.Errorf("sub-task Line = %d, want 6", p.Tasks[0].SubTasks[0].Line)
+	}
+}
+
+// --- Plan Editing Tests ---
+
+func TestSetTaskStatus_RewritesOnlyTheCheckbox(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	plan := "# Plan\n\n## Phase 1: Setup\n\n- [ ] Task: Add login  \n- [x] Task: Add logout `abc1234`\n    - [x] Sub\n"
+	if err := os.WriteFile(path, []byte(plan), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetTaskStatus(path, 5, TaskInProgress, ""); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	if err := SetTaskStatus(path, 6, TaskCompleted, "def5678"); err != nil {
+		t.Fatalf("SetTaskStatus returned error: %v", err)
+	}
+	got, _ := os.ReadFile(path)
+	want := "# Plan\n\n## Phase 1: Setup\n\n- [~] Task: Add login  \n- [x] Task: Add logout `def5678`\n    - [x] Sub\n"
+	if string(got) != want {
+		t.Errorf("plan =\n%q\nwant\n%q", got, want)
+	}
+	if task := ParsePlan(string(got))[0].Tasks[0]; TaskStatus(task) != TaskInProgress {
+		t.Errorf("TaskStatus = %q, want %q", TaskStatus(task), TaskInProgress)
+	}
+}
+
+func TestSetTaskStatus_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.md")
+	if err := os.WriteFile(path, []byte("## Phase 1: Setup\n- [ ] Task: A\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for name, err := range map[string]error{
+		"not a task":   SetTaskStatus(path, 1, TaskCompleted, ""),
+		"out of range": SetTaskStatus(path, 9, TaskCompleted, ""),
+		"bad status":   SetTaskStatus(path, 2, "done", ""),
+		"bad commit":   SetTaskStatus(path, 2, TaskCompleted, "HEAD"),
+	} {
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// --- Validation Tests ---
+
+func TestValidatePlan(t *testing.T) {
+	plan := "# Plan\n" +
+		"- [ ] Task: Before any phase\n" +
+		"## Phase 1: Setup\n" +
+		"- [ ] Task: Good `abc1234`\n" +
+		"    - [x] Good sub-task\n" +
+		"- [x] Task: Bad SHA `HEAD`\n" +
+		"- [X] Task: Capital X\n" +
+		"  - [ ] Two-space sub-task\n" +
+		"    - [~] In-progress sub-task\n" +
+		"- [ ] Untracked item\n" +
+		"### Phase 2: Wrong level\n" +
+		"## Phase 3: Skipped\n"
+	want := map[int]string{
+		2:  "task outside any phase",
+		6:  `"HEAD" is not a commit SHA`,
+		7:  "task is not recognised",
+		8:  "indent it by exactly 4 spaces",
+		9:  "cannot be marked in progress",
+		10: "checklist item is not tracked",
+		11: "heading is not read as a phase",
+		12: "phase 3 follows phase 1",
+	}
+	problems := ValidatePlan(plan)
+	if len(problems) != len(want) {
+		t.Errorf("got %d problems, want %d: %+v", len(problems), len(want), problems)
+	}
+	for _, p := range problems {
+		if !strings.Contains(p.Message, want[p.Line]) || want[p.Line] == "" {
+			t.Errorf("line %d: %q, want %q", p.Line, p.Message, want[p.Line])
+		}
+	}
+
+	if p := ValidatePlan("# Plan\n"); len(p) != 1 || p[0].Line != 0 || p[0].Severity != ProblemInfo {
+		t.Errorf("plan without phases = %+v", p)
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	valid, err := os.ReadFile("../../testdata/valid_metadata.json")
+	if err != nil {
+		t.Fatalf("failed to read test file: %v", err)
+	}
+	if p := ValidateMetadata(valid); len(p) != 0 {
+		t.Errorf("valid metadata problems = %+v", p)
+	}
+
+	p := ValidateMetadata([]byte("{\n  \"track_id\": \"x\",\n  \"status\": \"in_progress\",\n}\n"))
+	if len(p) != 1 || p[0].Severity != ProblemError || p[0].Line != 4 {
+		t.Errorf("syntax error = %+v", p)
+	}
+
+	p = ValidateMetadata([]byte("{\n  \"status\": \"doing\",\n  \"type\": \"feature\",\n  \"description\": \"d\",\n  \"created_at\": \"yesterday\"\n}"))
+	got := map[int]string{}
+	for _, pr := range p {
+		got[pr.Line] = pr.Severity + ": " + pr.Message
+	}
+	want := map[int]string{
+		0: "warning: track_id is missing",
+		2: `info: status "doing" is not one of`,
+		5: "warning: created_at is not an RFC 3339 timestamp",
+	}
+	if len(got) != len(want) {
+		t.Errorf("problems = %+v", p)
+	}
+	for line, w := range want {
+		if !strings.HasPrefix(got[line], w) {
+			t.Errorf("line %d: %q, want %q", line, got[line], w)
+		}
+	}
+}
+
+func TestPhaseStatus(t *testing.T) {
+	tests := []struct {
+		name  string
+		phase Phase
+		want  string
+	}{
+		{
+			name:  "empty phase",
+			phase: Phase{Tasks: []Task{}},
+			want:  "empty",
+		},
+		{
+			name: "all completed",
+			phase: Phase{Tasks: []Task{
+				{Completed: true},
+				{Completed: true},
+			}},
+			want: "completed",
+		},
+		{
+			name: "some completed",
+			phase: Phase{Tasks: []Task{
+				{Completed: true},
+				{Completed: false},
+			}},
+			want: "in_progress",
+		},
+		{
+			name: "none completed",
+			phase: Phase{Tasks: []Task{
+				{Completed: false},
+				{Completed: false},
+			}},
+			want: "pending",
+		},
+	}
+	for _, tt := range tests {
+		got := PhaseStatus(tt.phase)
+		if got != tt.want {
+			t.Errorf("PhaseStatus(%s) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestProject_TracksAndTrack(t *testing.T) {
+	p := New("../../testdata/discovery")
+	got := p.Tracks(Filter{Statuses: []string{"in_progress"}})
+	if len(got) != 1 || got[0].TrackID != "feature-alpha_20260101" {
+		t.Errorf("Tracks(in_progress) = %+v", got)
+	}
+	if n := len(p.Tracks(Filter{Archived: true})); n != 3 {
+		t.Errorf("Tracks(archived) returned %d tracks, want 3", n)
+	}
+
+	track, err := p.Track("feature-gamma_20250601")
+	if err != nil || track.Source != "archived" || p.Dir(track) != filepath.Join("../../testdata/discovery", "conductor", "archive", "feature-gamma_20250601") {
+		t.Errorf("Track = %+v, %v", track, err)
+	}
+	if _, err := p.Track("missing"); !errors.Is(err, ErrTrackNotFound) || err.Error() != `track "missing" not found` {
+		t.Errorf("missing track error = %v", err)
+	}
+}
+
+const registry = RegistryHeader + `
+---
+
+- [x] **Track: Alpha feature**
+  *Link: [./tracks/alpha_20260101/](./tracks/alpha_20260101/)*
+
+---
+
+## [~] Track: Beta fix
+*Link: [./tracks/beta_20260102/](./tracks/beta_20260102/)*
+`
+
+func TestParseRegistry(t *testing.T) {
+	got := ParseRegistry(registry)
+	want := []RegistryEntry{
+		{TrackID: "alpha_20260101", Description: "Alpha feature", Status: "completed", Link: "./tracks/alpha_20260101/", Line: 7},
+		{TrackID: "beta_20260102", Description: "Beta fix", Status: "in_progress", Link: "./tracks/beta_20260102/", Line: 12},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("ParseRegistry =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestRegistry_AddSetRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tracks.md")
+	if err := AddRegistryEntry(path, RegistryEntry{TrackID: "alpha_20260101", Description: "Alpha feature"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddRegistryEntry(path, RegistryEntry{TrackID: "beta_20260102", Description: "Beta fix", Status: "in_progress"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddRegistryEntry(path, RegistryEntry{TrackID: "beta_20260102"}); err == nil {
+		t.Error("adding a track twice should fail")
+	}
+	if err := SetRegistryStatus(path, "alpha_20260101", "completed"); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(path)
+	want := RegistryHeader + "\n---\n\n- [x] **Track: Alpha feature**\n*Link: [./tracks/alpha_20260101/](./tracks/alpha_20260101/)*\n" +
+		"\n---\n\n- [~] **Track: Beta fix**\n*Link: [./tracks/beta_20260102/](./tracks/beta_20260102/)*\n"
+	if string(content) != want {
+		t.Fatalf("tracks.md =\n%s\nwant\n%s", content, want)
+	}
+
+	if err := RemoveRegistryEntry(path, "alpha_20260101"); err != nil {
+		t.Fatal(err)
+	}
+	content, _ = os.ReadFile(path)
+	if want := RegistryHeader + "\n---\n\n- [~] **Track: Beta fix**\n*Link: [./tracks/beta_20260102/](./tracks/beta_20260102/)*\n"; string(content) != want {
+		t.Errorf("after removing alpha =\n%s", content)
+	}
+	if err := RemoveRegistryEntry(path, "beta_20260102"); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ = os.ReadFile(path); string(content) != RegistryHeader {
+		t.Errorf("after removing beta =\n%q", content)
+	}
+	if err := RemoveRegistryEntry(path, "beta_20260102"); !errors.Is(err, ErrNotRegistered) {
+		t.Errorf("removing twice = %v", err)
+	}
+}
+
+func TestProject_SaveTrackSyncsRegistry(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	if err := AddRegistryEntry(p.RegistryPath(), RegistryEntry{TrackID: "bugfix-beta_20260102", Description: "Beta"}); err != nil {
+		t.Fatal(err)
+	}
+	track, err := p.Track("bugfix-beta_20260102")
+	if err != nil {
+		t.Fatal(err)
+	}
+	track.Status = "completed"
+	if err := p.SaveTrack(track); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := p.Registry()
+	if err != nil || len(entries) != 1 || entries[0].Status != "completed" {
+		t.Errorf("registry = %+v, %v", entries, err)
+	}
+
+	// Tracks missing from the registry are saved all the same.
+	track, _ = p.Track("feature-alpha_20260101")
+	track.Status = "completed"
+	if err := p.SaveTrack(track); err != nil {
+		t.Errorf("SaveTrack of an unregistered track: %v", err)
+	}
+	if track, _ = p.Track("feature-alpha_20260101"); track.Status != "completed" {
+		t.Errorf("status = %q", track.Status)
+	}
+}
+
+func TestProject_CreateTrack(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.UTC)
+	track, err := p.CreateTrack("login-flow", "feature", "  Add a\nlogin flow ", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if track.TrackID != "login-flow_20260304" || track.Description != "Add a login flow" {
+		t.Errorf("track = %+v", track)
+	}
+
+	got, err := p.Track("login-flow_20260304")
+	if err != nil || got.Status != "new" || got.Type != "feature" || !got.CreatedAt.Equal(now) || len(got.Phases) != 1 {
+		t.Errorf("created track = %+v, %v", got, err)
+	}
+	index, _ := os.ReadFile(filepath.Join(p.Dir(got), "index.md"))
+	if !strings.HasPrefix(string(index), "# Track login-flow_20260304 Context\n\n- [Specification](./spec.md)") {
+		t.Errorf("index.md =\n%s", index)
+	}
+	if _, err := os.Stat(filepath.Join(p.Dir(got), "spec.md")); err != nil {
+		t.Error(err)
+	}
+	entries, err := p.Registry()
+	if err != nil || len(entries) != 1 || entries[0].TrackID != "login-flow_20260304" || entries[0].Description != "Add a login flow" {
+		t.Errorf("registry = %+v, %v", entries, err)
+	}
+
+	for name, args := range map[string][3]string{
+		"duplicate":      {"login-flow", "bug", "Again"},
+		"archived name":  {"feature-gamma", "bug", "Again"},
+		"bad short name": {"Login Flow", "bug", "Again"},
+		"no description": {"other", "bug", " "},
+		"no type":        {"other", "", "Other"},
+	} {
+		if _, err := p.CreateTrack(args[0], args[1], args[2], now); err == nil {
+			t.Errorf("%s: CreateTrack should fail", name)
+		}
+	}
+	if entries, _ := p.Registry(); len(entries) != 1 {
+		t.Errorf("failed creates changed the registry: %+v", entries)
+	}
+}
+
+func TestProject_ArchiveAndUnarchive(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	if err := AddRegistryEntry(p.RegistryPath(), RegistryEntry{TrackID: "feature-alpha_20260101", Description: "Alpha", Status: "in_progress"}); err != nil {
+		t.Fatal(err)
+	}
+	track, _ := p.Track("feature-alpha_20260101")
+	files, err := p.TrackFiles(track)
+	if err != nil || fmt.Sprint(files) != "[metadata.json plan.md]" {
+		t.Errorf("TrackFiles = %v, %v", files, err)
+	}
+
+	archived, err := p.Archive(track, "completed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if archived.Source != "archived" || archived.Status != "completed" {
+		t.Errorf("archived = %+v", archived)
+	}
+	if _, err := os.Stat(filepath.Join(base, "conductor", "tracks", "feature-alpha_20260101")); !os.IsNotExist(err) {
+		t.Error("active directory should be gone")
+	}
+	if got, _ := p.Track("feature-alpha_20260101"); got.Source != "archived" || got.Status != "completed" || len(got.Phases) != 1 {
+		t.Errorf("reloaded = %+v", got)
+	}
+	if entries, _ := p.Registry(); len(entries) != 0 {
+		t.Errorf("registry after archive = %+v", entries)
+	}
+	if _, err := p.Archive(archived, ""); err == nil {
+		t.Error("archiving twice should fail")
+	}
+
+	restored, err := p.Unarchive(archived, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Source != "active" || restored.Status != "completed" {
+		t.Errorf("restored = %+v", restored)
+	}
+	entries, _ := p.Registry()
+	if len(entries) != 1 || entries[0].TrackID != "feature-alpha_20260101" || entries[0].Status != "completed" {
+		t.Errorf("registry after unarchive = %+v", entries)
+	}
+}
+
+func TestProject_ArchiveRollsBack(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	// A directory where tracks.md should be makes the registry update fail.
+	if err := os.Mkdir(p.RegistryPath(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	track, _ := p.Track("feature-gamma_20250601")
+	if _, err := p.Unarchive(track, "in_progress"); err == nil {
+		t.Fatal("Unarchive should fail")
+	}
+	got, err := p.Track("feature-gamma_20250601")
+	if err != nil || got.Source != "archived" || got.Status != track.Status {
+		t.Errorf("after rollback = %+v, %v", got, err)
+	}
+}
+
+func TestProject_RenameTrack(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	tracks := filepath.Join(base, "conductor", "tracks")
+	os.WriteFile(filepath.Join(tracks, "feature-alpha_20260101", "index.md"), []byte("# Track feature-alpha_20260101 Context\n"), 0o644)
+	os.WriteFile(filepath.Join(tracks, "bugfix-beta_20260102", "index.md"),
+		[]byte("- [Alpha](../feature-alpha_20260101/spec.md)\n- [Other](../feature-alpha_20260101x/)\n"), 0o644)
+	AddRegistryEntry(p.RegistryPath(), RegistryEntry{TrackID: "feature-alpha_20260101", Description: "Alpha"})
+
+	track, _ := p.Track("feature-alpha_20260101")
+	renamed, err := p.RenameTrack(track, "alpha_20260101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.Track("alpha_20260101")
+	if err != nil || got.Status != track.Status || len(got.Phases) != 1 || renamed.TrackID != "alpha_20260101" {
+		t.Errorf("renamed track = %+v, %v", got, err)
+	}
+	raw, _ := os.ReadFile(filepath.Join(tracks, "alpha_20260101", "metadata.json"))
+	if !strings.Contains(string(raw), `"track_id": "alpha_20260101"`) {
+		t.Errorf("metadata.json =\n%s", raw)
+	}
+	if entries, _ := p.Registry(); len(entries) != 1 || entries[0].Link != "./tracks/alpha_20260101/" {
+		t.Errorf("registry = %+v", entries)
+	}
+	own, _ := os.ReadFile(filepath.Join(tracks, "alpha_20260101", "index.md"))
+	sibling, _ := os.ReadFile(filepath.Join(tracks, "bugfix-beta_20260102", "index.md"))
+	if string(own) != "# Track alpha_20260101 Context\n" ||
+		string(sibling) != "- [Alpha](../alpha_20260101/spec.md)\n- [Other](../feature-alpha_20260101x/)\n" {
+		t.Errorf("index.md files =\n%s\n%s", own, sibling)
+	}
+
+	for _, id := range []string{"Alpha_20260101", "alpha", "alpha_2026", "bugfix-beta_20260102", "feature-gamma_20250601", "alpha_20260101"} {
+		if _, err := p.RenameTrack(renamed, id); err == nil {
+			t.Errorf("RenameTrack(%q) should fail", id)
+		}
+	}
+}
+
+func TestProject_RenameTrackRollsBack(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	AddRegistryEntry(p.RegistryPath(), RegistryEntry{TrackID: "feature-alpha_20260101", Description: "Alpha"})
+	before, _ := os.ReadFile(p.RegistryPath())
+	// An index.md that cannot be read fails the last step.
+	if err := os.Mkdir(filepath.Join(base, "conductor", "tracks", "bugfix-beta_20260102", "index.md"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	track, _ := p.Track("feature-alpha_20260101")
+	if _, err := p.RenameTrack(track, "alpha_20260101"); err == nil {
+		t.Fatal("RenameTrack should fail")
+	}
+	if got, err := p.Track("feature-alpha_20260101"); err != nil || got.Status != track.Status {
+		t.Errorf("track after rollback = %+v, %v", got, err)
+	}
+	if after, _ := os.ReadFile(p.RegistryPath()); string(after) != string(before) {
+		t.Errorf("registry after rollback =\n%s", after)
+	}
+	if _, err := os.Stat(filepath.Join(base, "conductor", "tracks", "alpha_20260101")); !os.IsNotExist(err) {
+		t.Error("renamed directory should be gone")
+	}
+}
+
+func TestWorkflow_SetStatus(t *testing.T) {
+	w := DefaultWorkflow
+	w.Transitions = map[string][]string{"new": {"in_progress", "cancelled"}}
+	day1 := time.Date(2026, 3, 1, 9, 0, 0, 500, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	track := Track{Status: "new"}
+	if err := w.SetStatus(&track, "completed", day1); err == nil || !strings.Contains(err.Error(), "in_progress, cancelled") {
+		t.Errorf("new -> completed: err = %v, want the allowed statuses", err)
+	}
+	if err := w.SetStatus(&track, "in_progress", day1); err != nil {
+		t.Fatal(err)
+	}
+	if !track.StartedAt.Equal(day1.Truncate(time.Second)) || !track.CompletedAt.IsZero() {
+		t.Errorf("after start = %+v", track)
+	}
+	if err := w.SetStatus(&track, "completed", day2); err != nil {
+		t.Fatal(err)
+	}
+	if !track.StartedAt.Equal(day1.Truncate(time.Second)) || !track.CompletedAt.Equal(day2.Truncate(time.Second)) {
+		t.Errorf("after completion = %+v", track)
+	}
+	if err := w.SetStatus(&track, "in_progress", day2); err != nil {
+		t.Fatal(err)
+	}
+	if track.StartedAt.IsZero() || !track.CompletedAt.IsZero() {
+		t.Errorf("reopening should keep started_at and clear completed_at: %+v", track)
+	}
+	if err := w.SetStatus(&track, "new", day2); err != nil {
+		t.Fatal(err)
+	}
+	if !track.StartedAt.IsZero() {
+		t.Errorf("moving back to new should clear started_at: %+v", track)
+	}
+
+	var zero Workflow
+	track = Track{Status: "new", StartedAt: day1}
+	if err := zero.SetStatus(&track, "completed", day2); err != nil || !track.StartedAt.Equal(day1) || !track.CompletedAt.IsZero() {
+		t.Errorf("zero workflow: %+v, %v", track, err)
+	}
+}
+
+func TestSaveMetadata_LifecycleTimestamps(t *testing.T) {
+	metaPath := filepath.Join(t.TempDir(), "metadata.json")
+	started := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if err := SaveMetadata(metaPath, Track{TrackID: "a_20260101", Type: "feature", Status: "in_progress", Description: "A", StartedAt: started}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"started_at": "2026-03-01T09:30:00Z"`) || strings.Contains(string(data), "completed_at") {
+		t.Errorf("metadata.json:\n%s", data)
+	}
+	if errs := ValidateMetadata(data); len(errs) != 0 {
+		t.Errorf("ValidateMetadata = %v", errs)
+	}
+	track, err := LoadMetadata(data)
+	if err != nil || !track.StartedAt.Equal(started) || !track.CancelledAt.IsZero() || len(track.Fields) != 0 {
+		t.Errorf("LoadMetadata = %+v, %v", track, err)
+	}
+}
+
+func TestProject_SetStatus(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	p.Workflow.Transitions = map[string][]string{"in_progress": {"completed"}}
+	track, _ := p.Track("feature-alpha_20260101")
+	if _, err := p.SetStatus(track, "new"); err == nil {
+		t.Error("a disallowed move should fail")
+	}
+	if _, err := p.SetStatus(track, "completed"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := p.Track("feature-alpha_20260101"); got.Status != "completed" || got.CompletedAt.IsZero() {
+		t.Errorf("reloaded = %+v", got)
+	}
+
+	archived, err := p.Archive(track, "cancelled")
+	if err == nil {
+		t.Errorf("archiving as cancelled from in_progress should fail, got %+v", archived)
+	}
+}
+
+const editablePlan = `# Plan
+
+Intro prose.
+
+## Phase 1: Setup [checkpoint: abc1234]
+
+- [x] Task: Scaffold ` + "`def5678`" + `
+    - [x] Create layout
+    - [ ] Add CI
+- [~] Task: Configure
+
+---
+
+## Phase 2: Build
+
+- [ ] Task: Write code
+
+---
+
+## Phase 3: Ship
+
+Notes at the end.
+`
+
+// planOutline summarises the phases, tasks and sub-tasks of a plan.
+func planOutline(content string) string {
+	var parts []string
+	for _, ph := range ParsePlan(content) {
+		s := fmt.Sprintf("%d %s:", ph.Number, ph.Name)
+		for _, t := range ph.Tasks {
+			s += " " + t.Name
+			if len(t.SubTasks) > 0 {
+				var subs []string
+				for _, st := range t.SubTasks {
+					subs = append(subs, st.Name)
+				}
+				s += "(" + strings.Join(subs, ", ") + ")"
+			}
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " | ")
+}
+
+func TestPlanEdits(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		edit       func(string) (string, error)
+		want       string
+		separators int
+	}{
+		{"insert phase", func(c string) (string, error) { return InsertPhase(c, 5, "Review") },
+			"1 Setup: Scaffold(Create layout, Add CI) Configure | 2 Review: | 3 Build: Write code | 4 Ship:", 3},
+		{"insert first phase", func(c string) (string, error) { return InsertPhase(c, 0, "Design") },
+			"1 Design: | 2 Setup: Scaffold(Create layout, Add CI) Configure | 3 Build: Write code | 4 Ship:", 3},
+		{"insert last phase", func(c string) (string, error) { return InsertPhase(c, 20, "Polish") },
+			"1 Setup: Scaffold(Create layout, Add CI) Configure | 2 Build: Write code | 3 Ship: | 4 Polish:", 3},
+		{"insert task", func(c string) (string, error) { return InsertTask(c, 7, "Lint") },
+			"1 Setup: Scaffold(Create layout, Add CI) Lint Configure | 2 Build: Write code | 3 Ship:", 2},
+		{"insert task into empty phase", func(c string) (string, error) { return InsertTask(c, 20, "Tag") },
+			"1 Setup: Scaffold(Create layout, Add CI) Configure | 2 Build: Write code | 3 Ship: Tag", 2},
+		{"insert sub-task", func(c string) (string, error) { return InsertSubTask(c, 8, "Docs") },
+			"1 Setup: Scaffold(Create layout, Docs, Add CI) Configure | 2 Build: Write code | 3 Ship:", 2},
+		{"rename", func(c string) (string, error) {
+			c, _ = RenamePlanItem(c, 5, "Bootstrap")
+			c, _ = RenamePlanItem(c, 7, "Scaffold it")
+			return RenamePlanItem(c, 8, "Make dirs")
+		}, "1 Bootstrap: Scaffold it(Make dirs, Add CI) Configure | 2 Build: Write code | 3 Ship:", 2},
+		{"move down", func(c string) (string, error) { return MoveTask(c, 7, 1) },
+			"1 Setup: Configure Scaffold(Create layout, Add CI) | 2 Build: Write code | 3 Ship:", 2},
+		{"move to phase", func(c string) (string, error) { return MoveTaskToPhase(c, 10, 14) },
+			"1 Setup: Scaffold(Create layout, Add CI) | 2 Build: Write code Configure | 3 Ship:", 2},
+		{"move to empty phase", func(c string) (string, error) { return MoveTaskToPhase(c, 7, 20) },
+			"1 Setup: Configure | 2 Build: Write code | 3 Ship: Scaffold(Create layout, Add CI)", 2},
+		{"delete sub-task", func(c string) (string, error) { return DeletePlanItem(c, 9) },
+			"1 Setup: Scaffold(Create layout) Configure | 2 Build: Write code | 3 Ship:", 2},
+		{"delete task", func(c string) (string, error) { return DeletePlanItem(c, 7) },
+			"1 Setup: Configure | 2 Build: Write code | 3 Ship:", 2},
+		{"delete phase", func(c string) (string, error) { return DeletePlanItem(c, 5) },
+			"1 Build: Write code | 2 Ship:", 1},
+		{"delete last phase", func(c string) (string, error) { return DeletePlanItem(c, 20) },
+			"1 Setup: Scaffold(Create layout, Add CI) Configure | 2 Build: Write code", 1},
+	} {
+		got, err := tc.edit(editablePlan)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		if outline := planOutline(got); outline != tc.want {
+			t.Errorf("%s: outline = %q, want %q", tc.name, outline, tc.want)
+		}
+		if n := strings.Count(got, "\n---\n"); n != tc.separators {
+			t.Errorf("%s: %d separators, want %d:\n%s", tc.name, n, tc.separators, got)
+		}
+		for _, keep := range []string{"# Plan\n\nIntro prose.\n\n## Phase 1", "Notes at the end.\n"} {
+			if !strings.Contains(got, keep) {
+				t.Errorf("%s: lost %q:\n%s", tc.name, keep, got)
+			}
+		}
+		if strings.Contains(got, "\n\n\n") || !strings.HasSuffix(got, "\n") {
+			t.Errorf("%s: blank lines not kept tidy:\n%q", tc.name, got)
+		}
+		if tc.name != "delete phase" && !strings.Contains(got, "[checkpoint: abc1234]") {
+			t.Errorf("%s: checkpoint lost", tc.name)
+		}
+	}
+}
+
+func TestPlanEdits_Errors(t *testing.T) {
+	for name, edit := range map[string]func(string) (string, error){
+		"empty name":       func(c string) (string, error) { return InsertTask(c, 7, "  ") },
+		"multi-line name":  func(c string) (string, error) { return RenamePlanItem(c, 7, "a\nb") },
+		"not an item":      func(c string) (string, error) { return DeletePlanItem(c, 3) },
+		"sub-task as task": func(c string) (string, error) { return InsertTask(c, 8, "x") },
+		"first task up":    func(c string) (string, error) { return MoveTask(c, 7, -1) },
+		"to a task":        func(c string) (string, error) { return MoveTaskToPhase(c, 7, 16) },
+	} {
+		if _, err := edit(editablePlan); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPlanEdits_CRLFAndEmptyPlan(t *testing.T) {
+	crlf := strings.ReplaceAll(editablePlan, "\n", "\r\n")
+	got, err := InsertTask(crlf, 16, "Test code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.ReplaceAll(editablePlan, "Write code\n", "Write code\n- [ ] Task: Test code\n"); got != strings.ReplaceAll(want, "\n", "\r\n") {
+		t.Errorf("CRLF plan:\n%q", got)
+	}
+
+	got, err = InsertPhase("# Plan\n", 0, "Setup")
+	if err != nil || got != "# Plan\n\n## Phase 1: Setup\n" {
+		t.Errorf("empty plan = %q, %v", got, err)
+	}
+	if got = RenumberPhases("## Phase 3: A\n## Phase 7: B [checkpoint: abc1234]\n"); got != "## Phase 1: A\n## Phase 2: B [checkpoint: abc1234]\n" {
+		t.Errorf("RenumberPhases = %q", got)
+	}
+}
+
+func TestProject_EditPlan(t *testing.T) {
+	base := t.TempDir()
+	if err := os.CopyFS(base, os.DirFS("../../testdata/discovery")); err != nil {
+		t.Fatal(err)
+	}
+	p := New(base)
+	track, _ := p.Track("feature-alpha_20260101")
+	before, after, err := p.EditPlan(track, func(c string) (string, error) { return InsertTask(c, 6, "Write docs") })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := p.Track(track.TrackID); len(got.Phases[0].Tasks) != 3 || got.Phases[0].Tasks[2].Name != "Write docs" {
+		t.Errorf("reloaded = %+v", got.Phases)
+	}
+	if strings.Contains(before, "Write docs") || !strings.Contains(after, "Write docs") {
+		t.Errorf("before/after = %q, %q", before, after)
+	}
+	if _, _, err := p.EditPlan(track, func(c string) (string, error) { return DeletePlanItem(c, 1) }); err == nil {
+		t.Error("a failing edit should be reported")
+	}
+}
@@ -0,0 +1,108 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// shortNameRe matches a track short name: lowercase words joined by hyphens.
+var shortNameRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// TrackID returns the ID of a track named shortName and created on day:
+// shortname_YYYYMMDD.
+func TrackID(shortName string, day time.Time) string {
+	return shortName + "_" + day.Format("20060102")
+}
+
+// ShortName returns the short name part of a shortname_YYYYMMDD track ID,
+// or the whole ID when it has no date suffix.
+func ShortName(trackID string) string {
+	if i := strings.LastIndex(trackID, "_"); i > 0 {
+		return trackID[:i]
+	}
+	return trackID
+}
+
+// ValidateShortName reports whether name can start a track ID.
+func ValidateShortName(name string) error {
+	if name == "" {
+		return errors.New("short name is required")
+	}
+	if !shortNameRe.MatchString(name) {
+		return fmt.Errorf("short name %q must be lowercase letters and digits separated by hyphens", name)
+	}
+	return nil
+}
+
+// CreateTrack scaffolds a new track the way the /conductor:new-track skill
+// does: conductor/tracks/<shortname_YYYYMMDD>/ with metadata.json (status
+// "new"), index.md, and spec.md and plan.md skeletons, plus a section
+// appended to conductor/tracks.md. It fails when an active or archived
+// track already uses shortName. Nothing is left behind on failure.
+func (p Project) CreateTrack(shortName, typ, description string, now time.Time) (Track, error) {
+	if err := ValidateShortName(shortName); err != nil {
+		return Track{}, err
+	}
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return Track{}, errors.New("description is required")
+	}
+	if typ == "" {
+		return Track{}, errors.New("type is required")
+	}
+	for _, dir := range []string{"tracks", "archive"} {
+		entries, _ := os.ReadDir(filepath.Join(p.BasePath, "conductor", dir))
+		for _, e := range entries {
+			if e.IsDir() && ShortName(e.Name()) == shortName {
+				return Track{}, fmt.Errorf("a track named %q already exists: %s", shortName, e.Name())
+			}
+		}
+	}
+
+	now = now.UTC().Truncate(time.Second)
+	t := Track{
+		TrackID:     TrackID(shortName, now),
+		Type:        typ,
+		Status:      "new",
+		Description: description,
+		Source:      "active",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	dir := p.Dir(t)
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return Track{}, err
+	}
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		return Track{}, err
+	}
+	if err := p.scaffold(t); err != nil {
+		os.RemoveAll(dir)
+		return Track{}, err
+	}
+	return t, nil
+}
+
+func (p Project) scaffold(t Track) error {
+	dir := p.Dir(t)
+	if err := SaveMetadata(filepath.Join(dir, "metadata.json"), t); err != nil {
+		return err
+	}
+	files := []struct{ name, content string }{
+		{"index.md", "# Track " + t.TrackID + " Context\n\n- [Specification](./spec.md)\n- [Implementation Plan](./plan.md)\n- [Metadata](./metadata.json)\n"},
+		{"spec.md", "# Specification: " + t.Description + "\n\n## Overview\n\n" + t.Description +
+			"\n\n## Functional Requirements\n\n## Acceptance Criteria\n\n## Out of Scope\n"},
+		{"plan.md", "# Implementation Plan: " + t.Description + "\n\n## Phase 1: Implementation\n"},
+	}
+	for _, f := range files {
+		if err := WriteFileAtomic(filepath.Join(dir, f.name), []byte(f.content)); err != nil {
+			return err
+		}
+	}
+	return AddRegistryEntry(p.RegistryPath(), RegistryEntry{TrackID: t.TrackID, Description: t.Description, Status: t.Status})
+}
@@ -1,4 +1,4 @@
-package data
+package conductor
 
 import (
 	"os"
@@ -30,29 +30,10 @@ func DiscoverTracks(basePath string) []Track {
 				continue
 			}
 
-			metaPath := filepath.Join(d.path, entry.Name(), "metadata.json")
-			metaData, err := os.ReadFile(metaPath)
+			track, err := LoadTrack(filepath.Join(d.path, entry.Name()), d.source)
 			if err != nil {
 				continue
 			}
-
-			track, err := LoadMetadata(metaData)
-			if err != nil {
-				continue
-			}
-
-			if track.TrackID == "" {
-				track.TrackID = entry.Name()
-			}
-			track.Source = d.source
-
-			// Try to parse plan.md
-			planPath := filepath.Join(d.path, entry.Name(), "plan.md")
-			planData, err := os.ReadFile(planPath)
-			if err == nil {
-				track.Phases = ParsePlan(string(planData))
-			}
-
 			tracks = append(tracks, track)
 		}
 	}
@@ -60,6 +41,39 @@ func DiscoverTracks(basePath string) []Track {
 	return SortTracks(tracks)
 }
 
+// LoadTrack loads the track in dir from its metadata.json and, when it
+// exists, its plan.md. The track ID defaults to the directory name and
+// source is recorded as the track's Source.
+func LoadTrack(dir, source string) (Track, error) {
+	metaData, err := os.ReadFile(filepath.Join(dir, "metadata.json"))
+	if err != nil {
+		return Track{}, err
+	}
+	track, err := LoadMetadata(metaData)
+	if err != nil {
+		return Track{}, err
+	}
+	if track.TrackID == "" {
+		track.TrackID = filepath.Base(dir)
+	}
+	track.Source = source
+	if planData, err := os.ReadFile(filepath.Join(dir, "plan.md")); err == nil {
+		track.Phases = ParsePlan(string(planData))
+	}
+	return track, nil
+}
+
+// TrackDir returns the directory holding the track under basePath:
+// conductor/tracks/<id> for active tracks, conductor/archive/<id> for
+// archived ones.
+func TrackDir(basePath string, t Track) string {
+	dir := "tracks"
+	if t.Source == "archived" {
+		dir = "archive"
+	}
+	return filepath.Join(basePath, "conductor", dir, t.TrackID)
+}
+
 // SortTracks sorts tracks: active before archived, then by creation date
 // (newest first) within each group. Tracks with missing created_at are
 // sorted to the bottom of their group.
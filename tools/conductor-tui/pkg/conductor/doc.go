@@ -0,0 +1,28 @@
+// Package conductor loads, queries and edits a Conductor project: the
+// tracks under conductor/tracks and conductor/archive, their metadata.json
+// and plan.md files, and the conductor/tracks.md registry.
+//
+// It is the public API of conductor-tui. The TUI and every subcommand read
+// and write the project through it, so other tools that import it see the
+// same tracks and make the same edits.
+//
+//	p := conductor.New(".")
+//	for _, t := range p.Tracks(conductor.Filter{Statuses: []string{"in_progress"}}) {
+//		fmt.Println(t.TrackID, conductor.TrackProgress(t).Percent())
+//	}
+//
+// # Stability
+//
+// The API is versioned by [APIVersion] using semantic versioning. Within a
+// major version exported identifiers are not removed or changed in
+// incompatible ways; minor versions only add to the API. That promise
+// starts with the first conductor-tui release that includes this package;
+// until then the API may still change without a version bump.
+//
+// Files are always written atomically with [WriteFileAtomic], and fields
+// of metadata.json or lines of plan.md and tracks.md that an edit does not
+// concern are kept.
+package conductor
+
+// APIVersion is the semantic version of this package's API.
+const APIVersion = "1.0.0"
@@ -0,0 +1,80 @@
+package conductor
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Task states, as written in plan.md checkboxes.
+const (
+	TaskPending    = "pending"     // - [ ]
+	TaskInProgress = "in_progress" // - [~]
+	TaskCompleted  = "completed"   // - [x]
+)
+
+// TaskStatusValues lists the task states in workflow order.
+var TaskStatusValues = []string{TaskPending, TaskInProgress, TaskCompleted}
+
+// StatusValues lists the track statuses in lifecycle order.
+var StatusValues = []string{"new", "in_progress", "completed", "cancelled"}
+
+// TypeValues lists the track types.
+var TypeValues = []string{"feature", "bug", "chore", "refactor"}
+
+var commitRe = regexp.MustCompile(`^[a-f0-9]{7,40}$`)
+
+// TaskStatus returns the state of t.
+func TaskStatus(t Task) string {
+	switch {
+	case t.Completed:
+		return TaskCompleted
+	case t.InProgress:
+		return TaskInProgress
+	}
+	return TaskPending
+}
+
+// SetTaskStatus rewrites the checkbox of the task on the given 1-based line
+// of the plan.md at path, leaving the rest of the file untouched. A non-empty
+// commit SHA is written after the task name, replacing any existing one.
+func SetTaskStatus(path string, line int, status, commit string) error {
+	mark := map[string]string{TaskPending: " ", TaskInProgress: "~", TaskCompleted: "x"}[status]
+	if mark == "" {
+		return fmt.Errorf("unknown task status %q (want %s)", status, strings.Join(TaskStatusValues, ", "))
+	}
+	if commit != "" && !commitRe.MatchString(commit) {
+		return fmt.Errorf("invalid commit SHA %q", commit)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(raw), "\n")
+	if line < 1 || line > len(lines) {
+		return fmt.Errorf("%s: line %d out of range", path, line)
+	}
+	text := lines[line-1]
+	m := TaskRe.FindStringSubmatch(text)
+	if m == nil {
+		return fmt.Errorf("%s:%d: not a task line", path, line)
+	}
+
+	text = text[:3] + mark + text[4:]
+	if commit != "" {
+		cr := ""
+		if strings.HasSuffix(text, "\r") {
+			cr = "\r"
+		}
+		body := strings.TrimRight(text, " \t\r")
+		if m[3] != "" {
+			body = strings.TrimSuffix(body, "`"+m[3]+"`")
+			body = strings.TrimRight(body, " \t")
+		}
+		text = body + " `" + commit + "`" + cr
+	}
+	lines[line-1] = text
+	return WriteFileAtomic(path, []byte(strings.Join(lines, "\n")))
+}
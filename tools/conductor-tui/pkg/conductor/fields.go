@@ -0,0 +1,221 @@
+package conductor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field kinds.
+const (
+	KindEnum   = "enum"   // one of Options
+	KindText   = "text"   // free text
+	KindDate   = "date"   // YYYY-MM-DD
+	KindNumber = "number" // a JSON number
+	KindList   = "list"   // a JSON array of strings, typed comma-separated
+)
+
+// FieldKinds lists the supported field kinds.
+var FieldKinds = []string{KindEnum, KindText, KindDate, KindNumber, KindList}
+
+// MetadataKeys lists the metadata.json keys Track models. Every other key
+// is kept in Track.Fields.
+var MetadataKeys = []string{
+	"track_id", "type", "status", "description",
+	"created_at", "updated_at", "started_at", "completed_at", "cancelled_at",
+}
+
+// Field describes an editable metadata.json field: the key it is stored
+// under, the label shown for it and the kind of value it holds.
+type Field struct {
+	Key       string   `json:"key"`
+	Label     string   `json:"label,omitempty"`
+	Kind      string   `json:"kind"`
+	Options   []string `json:"options,omitempty"`   // the values of an enum
+	Required  bool     `json:"required,omitempty"`  // an empty value is rejected
+	Multiline bool     `json:"multiline,omitempty"` // text spanning several lines
+}
+
+// Name returns the label of f, or its key when it has none.
+func (f Field) Name() string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Key
+}
+
+// Check reports whether f is a usable field definition.
+func (f Field) Check() error {
+	if f.Key == "" {
+		return errors.New("key is required")
+	}
+	if !slices.Contains(FieldKinds, f.Kind) {
+		return fmt.Errorf("field %q: unknown kind %q (want %s)", f.Key, f.Kind, strings.Join(FieldKinds, ", "))
+	}
+	if f.Kind == KindEnum && len(f.Options) == 0 {
+		return fmt.Errorf("field %q: an enum needs options", f.Key)
+	}
+	if f.Kind != KindEnum && len(f.Options) > 0 {
+		return fmt.Errorf("field %q: only an enum has options", f.Key)
+	}
+	if f.Multiline && f.Kind != KindText {
+		return fmt.Errorf("field %q: only a text field can be multiline", f.Key)
+	}
+	return nil
+}
+
+// Format returns the value raw of f as it is typed: a list is joined with
+// ", " and an unset value is empty.
+func (f Field) Format(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var list []string
+	if json.Unmarshal(raw, &list) == nil {
+		return strings.Join(list, ", ")
+	}
+	return string(raw)
+}
+
+// Parse converts typed input to the JSON value of f and validates it.
+// Surrounding whitespace is dropped and empty input unsets the field.
+func (f Field) Parse(input string) (json.RawMessage, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, f.Validate(nil)
+	}
+	var v any = input
+	switch f.Kind {
+	case KindNumber:
+		n, err := strconv.ParseFloat(input, 64)
+		if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, fmt.Errorf("%s must be a number", f.Name())
+		}
+		v = json.Number(strconv.FormatFloat(n, 'f', -1, 64))
+	case KindList:
+		var items []string
+		for _, item := range strings.Split(input, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v = items
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return raw, f.Validate(raw)
+}
+
+// Validate reports whether raw is a valid value of f. An unset value is
+// valid unless f is required.
+func (f Field) Validate(raw json.RawMessage) error {
+	if len(raw) == 0 || string(raw) == "null" || string(raw) == `""` || string(raw) == "[]" {
+		if f.Required {
+			return fmt.Errorf("%s is required", f.Name())
+		}
+		return nil
+	}
+	switch f.Kind {
+	case KindNumber:
+		var n float64
+		if json.Unmarshal(raw, &n) != nil {
+			return fmt.Errorf("%s must be a number", f.Name())
+		}
+	case KindList:
+		var list []string
+		if json.Unmarshal(raw, &list) != nil {
+			return fmt.Errorf("%s must be a list of strings", f.Name())
+		}
+	default:
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			return fmt.Errorf("%s must be a string", f.Name())
+		}
+		if f.Kind == KindEnum && !slices.Contains(f.Options, s) {
+			return fmt.Errorf("%s must be one of %s", f.Name(), strings.Join(f.Options, ", "))
+		}
+		if f.Kind == KindDate {
+			if _, err := time.Parse(time.DateOnly, s); err != nil {
+				return fmt.Errorf("%s must be a date (YYYY-MM-DD)", f.Name())
+			}
+		}
+		if !f.Multiline && strings.Contains(s, "\n") {
+			return fmt.Errorf("%s must be a single line", f.Name())
+		}
+	}
+	return nil
+}
+
+// Value returns the JSON value stored under key in t's metadata, or nil
+// when it is unset.
+func (t Track) Value(key string) json.RawMessage {
+	var s string
+	switch key {
+	case "track_id":
+		s = t.TrackID
+	case "type":
+		s = t.Type
+	case "status":
+		s = t.Status
+	case "description":
+		s = t.Description
+	default:
+		return t.Fields[key]
+	}
+	raw, _ := json.Marshal(s)
+	return raw
+}
+
+// SetValue stores raw under key in t's metadata; nil unsets it. The
+// timestamps cannot be set this way.
+func (t *Track) SetValue(key string, raw json.RawMessage) error {
+	if slices.Contains(MetadataKeys, key) {
+		var s string
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return fmt.Errorf("%s must be a string", key)
+			}
+		}
+		switch key {
+		case "track_id":
+			t.TrackID = s
+		case "type":
+			t.Type = s
+		case "status":
+			t.Status = s
+		case "description":
+			t.Description = s
+		default:
+			return fmt.Errorf("%s cannot be set", key)
+		}
+		return nil
+	}
+	// Copy the map so copies of t that share it are left alone.
+	t.Fields = maps.Clone(t.Fields)
+	if len(raw) == 0 {
+		delete(t.Fields, key)
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	if t.Fields == nil {
+		t.Fields = map[string]json.RawMessage{}
+	}
+	t.Fields[key] = buf.Bytes()
+	return nil
+}
@@ -0,0 +1,160 @@
+package conductor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// metadataJSON represents the raw JSON structure of metadata.json.
+type metadataJSON struct {
+	TrackID     string `json:"track_id"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+	StartedAt   string `json:"started_at,omitempty"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	CancelledAt string `json:"cancelled_at,omitempty"`
+}
+
+// LoadMetadata parses metadata.json bytes into a Track with fallback defaults.
+func LoadMetadata(data []byte) (Track, error) {
+	var raw metadataJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Track{}, fmt.Errorf("invalid metadata JSON: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return Track{}, fmt.Errorf("invalid metadata JSON: %w", err)
+	}
+	for _, key := range MetadataKeys {
+		delete(fields, key)
+	}
+
+	t := Track{
+		TrackID:     raw.TrackID,
+		Type:        raw.Type,
+		Status:      raw.Status,
+		Description: raw.Description,
+	}
+	if len(fields) > 0 {
+		t.Fields = fields
+	}
+
+	if t.Type == "" {
+		t.Type = "unknown"
+	}
+	if t.Status == "" {
+		t.Status = "unknown"
+	}
+
+	t.CreatedAt = parseTimestamp(raw.CreatedAt)
+	t.UpdatedAt = parseTimestamp(raw.UpdatedAt)
+	t.StartedAt = parseTimestamp(raw.StartedAt)
+	t.CompletedAt = parseTimestamp(raw.CompletedAt)
+	t.CancelledAt = parseTimestamp(raw.CancelledAt)
+
+	return t, nil
+}
+
+// parseTimestamp parses an RFC 3339 timestamp, returning the zero time
+// for an empty or malformed one.
+func parseTimestamp(s string) time.Time {
+	parsed, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// formatTimestamp formats t as RFC 3339 in UTC, or "" for the zero time.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// SaveMetadata writes a Track's metadata to the given path as JSON.
+// It uses atomic write (write to temp file, then rename) and updates
+// the updated_at timestamp to the current time. The keys in Fields
+// follow the known ones, sorted.
+func SaveMetadata(path string, track Track) error {
+	raw := metadataJSON{
+		TrackID:     track.TrackID,
+		Type:        track.Type,
+		Status:      track.Status,
+		Description: track.Description,
+		CreatedAt:   formatTimestamp(track.CreatedAt),
+		UpdatedAt:   time.Now().UTC().Format(time.RFC3339),
+		StartedAt:   formatTimestamp(track.StartedAt),
+		CompletedAt: formatTimestamp(track.CompletedAt),
+		CancelledAt: formatTimestamp(track.CancelledAt),
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for _, key := range slices.Sorted(maps.Keys(track.Fields)) {
+		if slices.Contains(MetadataKeys, key) {
+			continue
+		}
+		name, _ := json.Marshal(key)
+		buf.WriteByte(',')
+		buf.Write(name)
+		buf.WriteByte(':')
+		if err := json.Compact(&buf, track.Fields[key]); err != nil {
+			return fmt.Errorf("failed to marshal metadata field %s: %w", key, err)
+		}
+	}
+	buf.WriteByte('}')
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	out.WriteByte('\n')
+	return WriteFileAtomic(path, out.Bytes())
+}
+
+// WriteFileAtomic writes content to a temp file next to path and renames it
+// into place so readers never see a partial file. Every write to the
+// conductor tree goes through it.
+func WriteFileAtomic(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpName, 0o644); err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+
+	if err := os.Rename(tmpName, path); err != nil {
+		os.Remove(tmpName)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return nil
+}
@@ -1,4 +1,4 @@
-package data
+package conductor
 
 import (
 	"regexp"
@@ -21,7 +21,7 @@ func ParsePlan(content string) []Phase {
 	var currentPhase *Phase
 	var currentTask *Task
 
-	for _, line := range strings.Split(content, "\n") {
+	for i, line := range strings.Split(content, "\n") {
 		if m := PhaseRe.FindStringSubmatch(line); m != nil {
 			if currentPhase != nil {
 				phases = append(phases, *currentPhase)
@@ -35,6 +35,7 @@ func ParsePlan(content string) []Phase {
 				Number:     num,
 				Name:       strings.TrimSpace(m[2]),
 				Checkpoint: checkpoint,
+				Line:       i + 1,
 			}
 			currentTask = nil
 			continue
@@ -46,9 +47,11 @@ func ParsePlan(content string) []Phase {
 				commit = m[3]
 			}
 			task := Task{
-				Name:      strings.TrimSpace(m[2]),
-				Completed: m[1] == "x",
-				Commit:    commit,
+				Name:       strings.TrimSpace(m[2]),
+				Completed:  m[1] == "x",
+				InProgress: m[1] == "~",
+				Commit:     commit,
+				Line:       i + 1,
 			}
 			currentPhase.Tasks = append(currentPhase.Tasks, task)
 			currentTask = &currentPhase.Tasks[len(currentPhase.Tasks)-1]
@@ -59,6 +62,7 @@ func ParsePlan(content string) []Phase {
 			currentTask.SubTasks = append(currentTask.SubTasks, SubTask{
 				Name:      strings.TrimSpace(m[2]),
 				Completed: m[1] == "x",
+				Line:      i + 1,
 			})
 		}
 	}
@@ -0,0 +1,421 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The plan edits below take the content of a plan.md and the 1-based line
+// of a phase heading, task or sub-task as ParsePlan reports it, and return
+// the edited content. Only the lines of the items involved are rewritten,
+// so prose, separators and checkpoint annotations around them are kept.
+// A task spans its own line and the indented lines below it, such as its
+// sub-tasks; a phase spans its heading up to the next heading.
+
+// separatorRe matches a markdown thematic break such as "---".
+var separatorRe = regexp.MustCompile(`^ {0,3}(?:(?:-[ \t]*){3,}|(?:\*[ \t]*){3,}|(?:_[ \t]*){3,})\r?$`)
+
+// planLines is a plan.md split into lines, remembering its line ending.
+type planLines struct {
+	lines []string
+	cr    string // "\r" when the plan uses CRLF line endings
+}
+
+func splitPlan(content string) *planLines {
+	p := &planLines{lines: strings.Split(content, "\n")}
+	if strings.HasSuffix(p.lines[0], "\r") {
+		p.cr = "\r"
+	}
+	return p
+}
+
+func (p *planLines) String() string { return strings.Join(p.lines, "\n") }
+
+// blank reports whether line i is empty or whitespace.
+func (p *planLines) blank(i int) bool {
+	return i < 0 || i >= len(p.lines) || strings.TrimSpace(p.lines[i]) == ""
+}
+
+// insert inserts text as lines before line index i, with the plan's line
+// ending.
+func (p *planLines) insert(i int, text ...string) {
+	for j := range text {
+		if text[j] != "" {
+			text[j] += p.cr
+		}
+	}
+	p.lines = append(p.lines[:i], append(text, p.lines[i:]...)...)
+}
+
+// remove removes the lines [start, end) and returns them. A blank line
+// left next to another blank line is dropped too.
+func (p *planLines) remove(start, end int) []string {
+	removed := append([]string(nil), p.lines[start:end]...)
+	p.lines = append(p.lines[:start], p.lines[end:]...)
+	if start > 0 && start < len(p.lines) && p.blank(start-1) && p.blank(start) {
+		p.lines = append(p.lines[:start], p.lines[start+1:]...)
+	}
+	return removed
+}
+
+// taskEnd returns the index of the first line after the task starting at
+// index i: the task line and the indented lines below it.
+func (p *planLines) taskEnd(i int) int {
+	j := i + 1
+	for j < len(p.lines) && !p.blank(j) && strings.IndexAny(p.lines[j][:1], " \t") == 0 {
+		j++
+	}
+	return j
+}
+
+// phaseEnd returns the index of the first line after phases[n]: the next
+// heading, or for the last phase the end of its last task, so that prose
+// after the plan is kept.
+func (p *planLines) phaseEnd(phases []Phase, n int) int {
+	if n+1 < len(phases) {
+		return phases[n+1].Line - 1
+	}
+	ph := phases[n]
+	if len(ph.Tasks) == 0 {
+		return ph.Line
+	}
+	return p.taskEnd(ph.Tasks[len(ph.Tasks)-1].Line - 1)
+}
+
+// phaseSeparator returns the thematic break the plan puts between phases,
+// or "" when it uses none.
+func (p *planLines) phaseSeparator(phases []Phase) string {
+	if len(phases) < 2 {
+		return ""
+	}
+	return p.separatorBefore(phases[1].Line - 1)
+}
+
+// separatorBefore returns the thematic break between the heading at index
+// i and the item before it, or "" when there is none.
+func (p *planLines) separatorBefore(i int) string {
+	for j := i - 1; j >= 0; j-- {
+		if !p.blank(j) {
+			if separatorRe.MatchString(p.lines[j]) {
+				return strings.TrimSpace(p.lines[j])
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// findItem locates the phase heading, task or sub-task on line. The task
+// and sub-task indexes are -1 when line is not a task or sub-task.
+func findItem(phases []Phase, line int) (phase, task, sub int, err error) {
+	for pi, ph := range phases {
+		if ph.Line == line {
+			return pi, -1, -1, nil
+		}
+		for ti, t := range ph.Tasks {
+			if t.Line == line {
+				return pi, ti, -1, nil
+			}
+			for si, st := range t.SubTasks {
+				if st.Line == line {
+					return pi, ti, si, nil
+				}
+			}
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("line %d: not a phase, task or sub-task", line)
+}
+
+// checkItemName reports whether name can be written as a plan item.
+func checkItemName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+	if strings.ContainsAny(name, "\r\n") {
+		return "", errors.New("name must be a single line")
+	}
+	return name, nil
+}
+
+// InsertPhase inserts a phase named name after the phase whose heading is
+// on line after, or before the first phase when after is 0, and renumbers
+// the phases. Plans that separate phases with "---" get one for the new
+// phase too.
+func InsertPhase(content string, after int, name string) (string, error) {
+	name, err := checkItemName(name)
+	if err != nil {
+		return "", err
+	}
+	phases := ParsePlan(content)
+	n := -1
+	for i, ph := range phases {
+		if ph.Line == after {
+			n = i
+		}
+	}
+	if after != 0 && n < 0 {
+		return "", fmt.Errorf("line %d: not a phase heading", after)
+	}
+	p := splitPlan(content)
+	heading := "## Phase 0: " + name // numbered by RenumberPhases
+	sep := p.phaseSeparator(phases)
+
+	if n+1 < len(phases) {
+		// Right before the next heading, after any separator closing phase n.
+		lines := []string{heading, ""}
+		if sep != "" {
+			lines = append(lines, sep, "")
+		}
+		p.insert(phases[n+1].Line-1, lines...)
+		return RenumberPhases(p.String()), nil
+	}
+
+	// After the last phase, or at the end of a plan without phases.
+	at := len(p.lines)
+	if n >= 0 {
+		at = p.phaseEnd(phases, n)
+	} else if p.lines[at-1] == "" {
+		at-- // keep the final newline last
+	}
+	var lines []string
+	if at > 0 && !p.blank(at-1) {
+		lines = append(lines, "")
+	}
+	if n >= 0 && sep != "" {
+		lines = append(lines, sep, "")
+	}
+	lines = append(lines, heading)
+	if at < len(p.lines) && !p.blank(at) {
+		lines = append(lines, "")
+	}
+	p.insert(at, lines...)
+	return RenumberPhases(p.String()), nil
+}
+
+// InsertTask inserts a pending task named name below the task on line
+// after, or as the first task of the phase whose heading is on line after.
+func InsertTask(content string, after int, name string) (string, error) {
+	name, err := checkItemName(name)
+	if err != nil {
+		return "", err
+	}
+	phases := ParsePlan(content)
+	pi, ti, si, err := findItem(phases, after)
+	if err != nil || si >= 0 {
+		return "", fmt.Errorf("line %d: not a phase heading or task", after)
+	}
+	p := splitPlan(content)
+	line := "- [ ] Task: " + name
+	switch {
+	case ti >= 0:
+		p.insert(p.taskEnd(after-1), line)
+	case len(phases[pi].Tasks) > 0:
+		p.insert(phases[pi].Tasks[0].Line-1, line)
+	default:
+		p.insertFirstTask(after-1, line)
+	}
+	return p.String(), nil
+}
+
+// insertFirstTask inserts lines as the tasks of the empty phase whose
+// heading is at index i, separated from the heading and whatever follows
+// by a blank line.
+func (p *planLines) insertFirstTask(i int, lines ...string) {
+	at := i + 1
+	if at+1 < len(p.lines) && p.blank(at) {
+		at++ // after the blank line below the heading
+	} else {
+		lines = append([]string{""}, lines...)
+	}
+	if at < len(p.lines) && !p.blank(at) {
+		lines = append(lines, "")
+	}
+	p.insert(at, lines...)
+}
+
+// InsertSubTask inserts a pending sub-task named name below the sub-task
+// on line after, or as the last sub-task of the task on line after.
+func InsertSubTask(content string, after int, name string) (string, error) {
+	name, err := checkItemName(name)
+	if err != nil {
+		return "", err
+	}
+	_, ti, si, err := findItem(ParsePlan(content), after)
+	if err != nil || ti < 0 {
+		return "", fmt.Errorf("line %d: not a task or sub-task", after)
+	}
+	p := splitPlan(content)
+	at := after
+	if si < 0 {
+		at = p.taskEnd(after - 1)
+	}
+	p.insert(at, "    - [ ] "+name)
+	return p.String(), nil
+}
+
+// RenamePlanItem renames the phase, task or sub-task on line, keeping its
+// number, checkbox, commit SHA and checkpoint.
+func RenamePlanItem(content string, line int, name string) (string, error) {
+	name, err := checkItemName(name)
+	if err != nil {
+		return "", err
+	}
+	if _, _, _, err := findItem(ParsePlan(content), line); err != nil {
+		return "", err
+	}
+	p := splitPlan(content)
+	text := strings.TrimSuffix(p.lines[line-1], "\r")
+	if m := PhaseRe.FindStringSubmatch(text); m != nil {
+		text = "## Phase " + m[1] + ": " + name
+		if m[3] != "" {
+			text += " [checkpoint: " + m[3] + "]"
+		}
+	} else if m := TaskRe.FindStringSubmatch(text); m != nil {
+		text = "- [" + m[1] + "] Task: " + name
+		if m[3] != "" {
+			text += " `" + m[3] + "`"
+		}
+	} else if m := SubtaskRe.FindStringSubmatch(text); m != nil {
+		text = "    - [" + m[1] + "] " + name
+	}
+	p.lines[line-1] = text + p.cr
+	return p.String(), nil
+}
+
+// MoveTask swaps the task on line, with its sub-tasks, with the task delta
+// places away in the same phase.
+func MoveTask(content string, line, delta int) (string, error) {
+	phases := ParsePlan(content)
+	pi, ti, si, err := findItem(phases, line)
+	if err != nil || ti < 0 || si >= 0 {
+		return "", fmt.Errorf("line %d: not a task", line)
+	}
+	tasks := phases[pi].Tasks
+	to := ti + delta
+	if to < 0 || to >= len(tasks) {
+		edge := "first"
+		if delta > 0 {
+			edge = "last"
+		}
+		return "", fmt.Errorf("task %q is already the %s task of phase %d", tasks[ti].Name, edge, phases[pi].Number)
+	}
+	first, second := tasks[min(ti, to)].Line-1, tasks[max(ti, to)].Line-1
+	p := splitPlan(content)
+	firstEnd, secondEnd := p.taskEnd(first), p.taskEnd(second)
+	var lines []string
+	lines = append(lines, p.lines[:first]...)
+	lines = append(lines, p.lines[second:secondEnd]...)
+	lines = append(lines, p.lines[firstEnd:second]...)
+	lines = append(lines, p.lines[first:firstEnd]...)
+	lines = append(lines, p.lines[secondEnd:]...)
+	p.lines = lines
+	return p.String(), nil
+}
+
+// MoveTaskToPhase moves the task on line, with its sub-tasks, to the end
+// of the phase whose heading is on line phase.
+func MoveTaskToPhase(content string, line, phase int) (string, error) {
+	phases := ParsePlan(content)
+	pi, ti, si, err := findItem(phases, line)
+	if err != nil || ti < 0 || si >= 0 {
+		return "", fmt.Errorf("line %d: not a task", line)
+	}
+	to, tti, _, err := findItem(phases, phase)
+	if err != nil || tti >= 0 {
+		return "", fmt.Errorf("line %d: not a phase heading", phase)
+	}
+	if to == pi {
+		return content, nil
+	}
+	p := splitPlan(content)
+	block := p.remove(line-1, p.taskEnd(line-1))
+	for i := range block {
+		block[i] = strings.TrimSuffix(block[i], "\r")
+	}
+
+	// Locate the target phase again now that the block is gone.
+	phases = ParsePlan(p.String())
+	target := phases[to]
+	if len(target.Tasks) == 0 {
+		p.insertFirstTask(target.Line-1, block...)
+	} else {
+		p.insert(p.taskEnd(target.Tasks[len(target.Tasks)-1].Line-1), block...)
+	}
+	return p.String(), nil
+}
+
+// DeletePlanItem removes the phase, task or sub-task on line. A task goes
+// with its sub-tasks and a phase with everything up to the next heading;
+// the remaining phases are renumbered.
+func DeletePlanItem(content string, line int) (string, error) {
+	phases := ParsePlan(content)
+	pi, ti, si, err := findItem(phases, line)
+	if err != nil {
+		return "", err
+	}
+	p := splitPlan(content)
+	switch {
+	case si >= 0:
+		p.remove(line-1, line)
+	case ti >= 0:
+		p.remove(line-1, p.taskEnd(line-1))
+	default:
+		start, end := line-1, p.phaseEnd(phases, pi)
+		if pi == len(phases)-1 && pi > 0 {
+			// Take the separator before the last phase along with it.
+			for start > 0 && p.blank(start-1) {
+				start--
+			}
+			if start > 0 && separatorRe.MatchString(p.lines[start-1]) {
+				start--
+			}
+		}
+		p.remove(start, end)
+		return RenumberPhases(p.String()), nil
+	}
+	return p.String(), nil
+}
+
+// RenumberPhases numbers the phase headings of a plan 1, 2, 3, ... in the
+// order they appear.
+func RenumberPhases(content string) string {
+	p := splitPlan(content)
+	n := 0
+	for i, line := range p.lines {
+		if m := PhaseRe.FindStringSubmatchIndex(line); m != nil {
+			n++
+			p.lines[i] = line[:m[2]] + strconv.Itoa(n) + line[m[3]:]
+		}
+	}
+	return p.String()
+}
+
+// EditPlan applies edit to the plan.md at path and writes the result. It
+// returns the content before and after the edit, so that the caller can
+// undo it.
+func EditPlan(path string, edit func(string) (string, error)) (before, after string, err error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	before = string(raw)
+	if after, err = edit(before); err != nil {
+		return before, before, err
+	}
+	if after == before {
+		return before, after, nil
+	}
+	return before, after, WriteFileAtomic(path, []byte(after))
+}
+
+// EditPlan applies edit to the plan.md of t; see the package-level
+// EditPlan.
+func (p Project) EditPlan(t Track, edit func(string) (string, error)) (before, after string, err error) {
+	return EditPlan(filepath.Join(p.Dir(t), "plan.md"), edit)
+}
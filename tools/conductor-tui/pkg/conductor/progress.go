@@ -0,0 +1,56 @@
+package conductor
+
+// PhaseStatus derives a status string from a Phase's task completion.
+func PhaseStatus(p Phase) string {
+	if len(p.Tasks) == 0 {
+		return "empty"
+	}
+	done := 0
+	for _, t := range p.Tasks {
+		if t.Completed {
+			done++
+		}
+	}
+	if done == len(p.Tasks) {
+		return "completed"
+	}
+	if done > 0 {
+		return "in_progress"
+	}
+	return "pending"
+}
+
+// Progress counts a track's tasks by state.
+type Progress struct {
+	Total      int `json:"total"`
+	Done       int `json:"done"`
+	InProgress int `json:"in_progress"`
+	Pending    int `json:"pending"`
+}
+
+// Percent returns the share of completed tasks, 0-100.
+func (p Progress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// TrackProgress counts the tasks across all of a track's phases.
+func TrackProgress(t Track) Progress {
+	var p Progress
+	for _, ph := range t.Phases {
+		for _, task := range ph.Tasks {
+			p.Total++
+			switch {
+			case task.Completed:
+				p.Done++
+			case task.InProgress:
+				p.InProgress++
+			default:
+				p.Pending++
+			}
+		}
+	}
+	return p
+}
@@ -0,0 +1,84 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// ErrTrackNotFound is returned when no track has the requested ID.
+var ErrTrackNotFound = errors.New("track not found")
+
+// Project is a Conductor project: the directory holding conductor/.
+// Use New rather than a Project literal to follow DefaultWorkflow.
+type Project struct {
+	BasePath string
+	// Workflow governs status changes made by SetStatus, Archive and
+	// Unarchive. The zero Workflow allows every move and records no
+	// lifecycle timestamps.
+	Workflow Workflow
+}
+
+// New returns the project rooted at basePath, following DefaultWorkflow.
+func New(basePath string) Project {
+	return Project{BasePath: basePath, Workflow: DefaultWorkflow}
+}
+
+// Tracks discovers the project's tracks and returns those matching f, in
+// SortTracks order.
+func (p Project) Tracks(f Filter) []Track {
+	return FilterTracks(DiscoverTracks(p.BasePath), f)
+}
+
+// Track returns the active or archived track with the given ID. The error
+// wraps ErrTrackNotFound when there is none.
+func (p Project) Track(id string) (Track, error) {
+	if t, ok := FindTrack(DiscoverTracks(p.BasePath), id); ok {
+		return t, nil
+	}
+	return Track{}, notFoundError(id)
+}
+
+// notFoundError names the missing track and matches ErrTrackNotFound.
+type notFoundError string
+
+func (e notFoundError) Error() string { return fmt.Sprintf("track %q not found", string(e)) }
+func (e notFoundError) Unwrap() error { return ErrTrackNotFound }
+
+// Dir returns the directory holding t.
+func (p Project) Dir(t Track) string {
+	return TrackDir(p.BasePath, t)
+}
+
+// RegistryPath returns the path of conductor/tracks.md.
+func (p Project) RegistryPath() string {
+	return filepath.Join(p.BasePath, "conductor", "tracks.md")
+}
+
+// Registry parses conductor/tracks.md.
+func (p Project) Registry() ([]RegistryEntry, error) {
+	return LoadRegistry(p.RegistryPath())
+}
+
+// SaveTrack writes t's metadata.json and updates the status mark of its
+// tracks.md entry, if it has one.
+func (p Project) SaveTrack(t Track) error {
+	if err := SaveMetadata(filepath.Join(p.Dir(t), "metadata.json"), t); err != nil {
+		return err
+	}
+	if t.Source == "archived" {
+		return nil // archived tracks are not in the registry
+	}
+	err := SetRegistryStatus(p.RegistryPath(), t.TrackID, t.Status)
+	if errors.Is(err, ErrNotRegistered) || errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// SetTaskStatus rewrites the task of t starting at line in plan.md; see
+// the package-level SetTaskStatus.
+func (p Project) SetTaskStatus(t Track, line int, status, commit string) error {
+	return SetTaskStatus(filepath.Join(p.Dir(t), "plan.md"), line, status, commit)
+}
@@ -0,0 +1,42 @@
+package conductor
+
+import "slices"
+
+// Filter selects tracks. Empty lists match every status or type.
+type Filter struct {
+	Statuses []string
+	Types    []string
+	Archived bool // include archived tracks
+}
+
+// Match reports whether t passes the filter.
+func (f Filter) Match(t Track) bool {
+	switch {
+	case !f.Archived && t.Source == "archived",
+		len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status),
+		len(f.Types) > 0 && !slices.Contains(f.Types, t.Type):
+		return false
+	}
+	return true
+}
+
+// FilterTracks returns the tracks matching f, keeping their order.
+func FilterTracks(tracks []Track, f Filter) []Track {
+	var out []Track
+	for _, t := range tracks {
+		if f.Match(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// FindTrack returns the track with the given ID.
+func FindTrack(tracks []Track, id string) (Track, bool) {
+	for _, t := range tracks {
+		if t.TrackID == id {
+			return t, true
+		}
+	}
+	return Track{}, false
+}
@@ -0,0 +1,187 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// RegistryHeader starts a new conductor/tracks.md.
+const RegistryHeader = "# Project Tracks\n\nThis file tracks all major tracks for the project. Each track has its own detailed plan in its respective folder.\n"
+
+// ErrNotRegistered is returned when tracks.md has no entry for a track.
+var ErrNotRegistered = errors.New("track is not in the registry")
+
+// RegistryEntry is one track section of conductor/tracks.md:
+//
+//	---
+//
+//	- [ ] **Track: Description**
+//	*Link: [./tracks/<id>/](./tracks/<id>/)*
+//
+// The "## [ ] Track: Description" heading form is read as well.
+type RegistryEntry struct {
+	TrackID     string // last element of Link; empty when there is no link
+	Description string
+	Status      string // "new", "in_progress" or "completed", from [ ], [~] or [x]
+	Link        string // e.g. "./tracks/<id>/"
+	Line        int    // 1-based line of the Track: line
+}
+
+var (
+	registryTrackRe = regexp.MustCompile(`^(?:[-*]\s+|#{1,6}\s+)\[([ xX~])\]\s+(?:\*\*)?Track:\s*(.*?)(?:\*\*)?\s*$`)
+	registryLinkRe  = regexp.MustCompile(`^\s*\*?Link:\s*\[[^\]]*\]\(([^)\s]+)\)\*?\s*$`)
+	registryRuleRe  = regexp.MustCompile(`^\s*---+\s*$`)
+)
+
+// ParseRegistry reads the track entries of a tracks.md.
+func ParseRegistry(content string) []RegistryEntry {
+	var entries []RegistryEntry
+	for i, line := range strings.Split(content, "\n") {
+		if m := registryTrackRe.FindStringSubmatch(line); m != nil {
+			entries = append(entries, RegistryEntry{
+				Description: m[2],
+				Status:      markStatus(m[1]),
+				Line:        i + 1,
+			})
+			continue
+		}
+		if registryRuleRe.MatchString(line) || len(entries) == 0 {
+			continue
+		}
+		e := &entries[len(entries)-1]
+		if m := registryLinkRe.FindStringSubmatch(line); m != nil && e.Link == "" {
+			e.Link = m[1]
+			e.TrackID = path.Base(strings.TrimSuffix(m[1], "/"))
+		}
+	}
+	return entries
+}
+
+// LoadRegistry reads and parses the tracks.md at path.
+func LoadRegistry(path string) ([]RegistryEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegistry(string(content)), nil
+}
+
+// AddRegistryEntry appends a section for e to the tracks.md at path,
+// creating the file with RegistryHeader if it does not exist. An empty
+// Link defaults to ./tracks/<TrackID>/.
+func AddRegistryEntry(path string, e RegistryEntry) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		content, err = []byte(RegistryHeader), nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, existing := range ParseRegistry(string(content)) {
+		if existing.TrackID == e.TrackID {
+			return fmt.Errorf("track %q is already in the registry", e.TrackID)
+		}
+	}
+	link := e.Link
+	if link == "" {
+		link = "./tracks/" + e.TrackID + "/"
+	}
+
+	s := strings.TrimRight(string(content), "\n")
+	s += fmt.Sprintf("\n\n---\n\n- [%s] **Track: %s**\n*Link: [%s](%s)*\n", statusMark(e.Status), e.Description, link, link)
+	return WriteFileAtomic(path, []byte(s))
+}
+
+// RemoveRegistryEntry deletes the section of trackID from the tracks.md
+// at path, from its --- rule up to the next one.
+func RemoveRegistryEntry(path, trackID string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	e, ok := findEntry(string(content), trackID)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrNotRegistered, trackID)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	start, end := e.Line-1, len(lines)
+	for i := start - 1; i >= 0; i-- {
+		if registryTrackRe.MatchString(lines[i]) {
+			break
+		}
+		if registryRuleRe.MatchString(lines[i]) {
+			start = i
+			break
+		}
+	}
+	for i := e.Line; i < len(lines); i++ {
+		if registryRuleRe.MatchString(lines[i]) || registryTrackRe.MatchString(lines[i]) {
+			end = i
+			break
+		}
+	}
+	lines = append(lines[:start], lines[end:]...)
+	s := strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+	return WriteFileAtomic(path, []byte(s))
+}
+
+// SetRegistryStatus sets the checkbox of trackID's entry in the tracks.md
+// at path to the mark for status: [~] for in_progress, [x] for completed
+// and [ ] otherwise.
+func SetRegistryStatus(path, trackID, status string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	e, ok := findEntry(string(content), trackID)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrNotRegistered, trackID)
+	}
+	lines := strings.Split(string(content), "\n")
+	line := lines[e.Line-1]
+	i := strings.Index(line, "[")
+	mark := statusMark(status)
+	if line[i+1:i+2] == mark {
+		return nil
+	}
+	lines[e.Line-1] = line[:i+1] + mark + line[i+2:]
+	return WriteFileAtomic(path, []byte(strings.Join(lines, "\n")))
+}
+
+func findEntry(content, trackID string) (RegistryEntry, bool) {
+	return findEntryIn(ParseRegistry(content), trackID)
+}
+
+func findEntryIn(entries []RegistryEntry, trackID string) (RegistryEntry, bool) {
+	for _, e := range entries {
+		if e.TrackID == trackID {
+			return e, true
+		}
+	}
+	return RegistryEntry{}, false
+}
+
+func markStatus(mark string) string {
+	switch mark {
+	case "~":
+		return "in_progress"
+	case "x", "X":
+		return "completed"
+	}
+	return "new"
+}
+
+func statusMark(status string) string {
+	switch status {
+	case "in_progress":
+		return "~"
+	case "completed":
+		return "x"
+	}
+	return " "
+}
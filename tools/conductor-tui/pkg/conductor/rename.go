@@ -0,0 +1,151 @@
+package conductor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ValidateTrackID reports whether id is a well-formed shortname_YYYYMMDD
+// track ID.
+func ValidateTrackID(id string) error {
+	i := strings.LastIndex(id, "_")
+	if i < 0 {
+		return fmt.Errorf("track ID %q must look like shortname_YYYYMMDD", id)
+	}
+	if err := ValidateShortName(id[:i]); err != nil {
+		return err
+	}
+	if _, err := time.Parse("20060102", id[i+1:]); err != nil {
+		return fmt.Errorf("track ID %q must end in a YYYYMMDD date", id)
+	}
+	return nil
+}
+
+// RenameTrack changes t's ID to newID: it renames the directory, rewrites
+// track_id in metadata.json, the link in conductor/tracks.md and every
+// mention of the old ID in the index.md files of the project's tracks.
+// If a step fails, the steps already done are undone.
+func (p Project) RenameTrack(t Track, newID string) (Track, error) {
+	if err := ValidateTrackID(newID); err != nil {
+		return t, err
+	}
+	if newID == t.TrackID {
+		return t, fmt.Errorf("track is already called %q", newID)
+	}
+	for _, source := range []string{"active", "archived"} {
+		if _, err := os.Stat(p.Dir(Track{TrackID: newID, Source: source})); err == nil {
+			return t, fmt.Errorf("a track called %q already exists", newID)
+		}
+	}
+
+	var undo []func() error
+	rollback := func(err error) (Track, error) {
+		for i := len(undo) - 1; i >= 0; i-- {
+			if uerr := undo[i](); uerr != nil {
+				err = fmt.Errorf("%w (rolling back also failed: %v)", err, uerr)
+			}
+		}
+		return t, err
+	}
+
+	renamed := t
+	renamed.TrackID = newID
+	from, to := p.Dir(t), p.Dir(renamed)
+	if err := os.Rename(from, to); err != nil {
+		return t, err
+	}
+	undo = append(undo, func() error { return os.Rename(to, from) })
+
+	// Each rewrite records the original content so it can be put back.
+	rewrite := func(path string, edit func(string) string) error {
+		old, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		updated := edit(string(old))
+		if updated == string(old) {
+			return nil
+		}
+		if err := WriteFileAtomic(path, []byte(updated)); err != nil {
+			return err
+		}
+		undo = append(undo, func() error { return WriteFileAtomic(path, old) })
+		return nil
+	}
+
+	metaPath := filepath.Join(to, "metadata.json")
+	oldMeta, err := os.ReadFile(metaPath)
+	if err != nil {
+		return rollback(err)
+	}
+	if err := SaveMetadata(metaPath, renamed); err != nil {
+		return rollback(err)
+	}
+	undo = append(undo, func() error { return WriteFileAtomic(metaPath, oldMeta) })
+
+	err = rewrite(p.RegistryPath(), func(content string) string {
+		return renameRegistryLink(content, t.TrackID, newID)
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return rollback(err)
+	}
+
+	indexes, _ := filepath.Glob(filepath.Join(p.BasePath, "conductor", "tracks", "*", "index.md"))
+	archived, _ := filepath.Glob(filepath.Join(p.BasePath, "conductor", "archive", "*", "index.md"))
+	for _, path := range append(indexes, archived...) {
+		if err := rewrite(path, func(content string) string {
+			return replaceID(content, t.TrackID, newID)
+		}); err != nil {
+			return rollback(err)
+		}
+	}
+	return renamed, nil
+}
+
+// renameRegistryLink points the link of oldID's registry entry at newID.
+func renameRegistryLink(content, oldID, newID string) string {
+	lines := strings.Split(content, "\n")
+	for _, e := range ParseRegistry(content) {
+		if e.TrackID != oldID {
+			continue
+		}
+		for i := e.Line; i < len(lines); i++ {
+			if registryLinkRe.MatchString(lines[i]) {
+				lines[i] = replaceID(lines[i], oldID, newID)
+				break
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// replaceID replaces whole-word occurrences of oldID in s: those not
+// preceded or followed by a character that can appear in a track ID.
+func replaceID(s, oldID, newID string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, oldID)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(oldID)
+		whole := (i == 0 || !isIDChar(s[i-1])) && (end == len(s) || !isIDChar(s[end]))
+		b.WriteString(s[:i])
+		if whole {
+			b.WriteString(newID)
+		} else {
+			b.WriteString(oldID)
+		}
+		s = s[end:]
+	}
+}
+
+func isIDChar(c byte) bool {
+	return c == '_' || c == '-' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
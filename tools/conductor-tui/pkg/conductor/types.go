@@ -0,0 +1,51 @@
+package conductor
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SubTask represents a sub-task within a task.
+type SubTask struct {
+	Name      string `json:"name"`
+	Completed bool   `json:"completed"`
+	Line      int    `json:"line"` // 1-based line number in plan.md
+}
+
+// Task represents a task within a phase.
+type Task struct {
+	Name       string    `json:"name"`
+	Completed  bool      `json:"completed"`
+	InProgress bool      `json:"in_progress"`      // marked [~]
+	Commit     string    `json:"commit,omitempty"` // short SHA or empty
+	SubTasks   []SubTask `json:"sub_tasks,omitempty"`
+	Line       int       `json:"line"` // 1-based line number in plan.md
+}
+
+// Phase represents a phase within a plan.
+type Phase struct {
+	Number     int    `json:"number"`
+	Name       string `json:"name"`
+	Checkpoint string `json:"checkpoint,omitempty"` // checkpoint SHA or empty
+	Tasks      []Task `json:"tasks"`
+	Line       int    `json:"line"` // 1-based line number in plan.md
+}
+
+// Track represents a discovered track with metadata and parsed plan.
+type Track struct {
+	TrackID     string    `json:"track_id"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Description string    `json:"description"`
+	Source      string    `json:"source"` // "active" or "archived"
+	CreatedAt   time.Time `json:"created_at,omitzero"`
+	UpdatedAt   time.Time `json:"updated_at,omitzero"`
+	StartedAt   time.Time `json:"started_at,omitzero"`
+	CompletedAt time.Time `json:"completed_at,omitzero"`
+	CancelledAt time.Time `json:"cancelled_at,omitzero"`
+	Phases      []Phase   `json:"phases"`
+
+	// Fields holds the metadata.json keys not listed in MetadataKeys, such
+	// as project-defined fields, as written. SaveMetadata writes them back.
+	Fields map[string]json.RawMessage `json:"fields,omitempty"`
+}
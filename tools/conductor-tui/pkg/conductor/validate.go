@@ -0,0 +1,189 @@
+package conductor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Problem severities, most severe first.
+const (
+	ProblemError   = "error"
+	ProblemWarning = "warning"
+	ProblemInfo    = "info"
+)
+
+// Problem is a validation finding in a plan.md or metadata.json.
+type Problem struct {
+	Line     int // 1-based; 0 when it concerns the whole file
+	Severity string
+	Message  string
+}
+
+var (
+	// checkboxRe matches anything that looks like a markdown checklist item.
+	checkboxRe = regexp.MustCompile(`^(\s*)[-*+]\s*\[(.?)\]`)
+	// phaseLikeRe matches headings that were probably meant as phases.
+	phaseLikeRe = regexp.MustCompile(`(?i)^#+\s*phase\b`)
+	// trailingCodeRe matches a trailing `...` span on a task line.
+	trailingCodeRe = regexp.MustCompile("`([^`]*)`\\s*$")
+)
+
+// ValidatePlan reports lines of a plan.md that ParsePlan skips or reads
+// differently than intended, and phase numbering mistakes.
+func ValidatePlan(content string) []Problem {
+	var out []Problem
+	add := func(line int, severity, format string, args ...any) {
+		out = append(out, Problem{Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	inPhase, inTask := false, false
+	lastPhase := 0
+	phases := 0
+	for i, line := range strings.Split(content, "\n") {
+		n := i + 1
+		if m := PhaseRe.FindStringSubmatch(line); m != nil {
+			num, _ := strconv.Atoi(m[1])
+			if num != lastPhase+1 {
+				add(n, ProblemWarning, "phase %d follows phase %d; expected phase %d", num, lastPhase, lastPhase+1)
+			}
+			lastPhase = num
+			inPhase, inTask = true, false
+			phases++
+			continue
+		}
+		if phaseLikeRe.MatchString(line) {
+			add(n, ProblemWarning, `heading is not read as a phase; use "## Phase N: Name"`)
+			continue
+		}
+		if m := TaskRe.FindStringSubmatch(line); m != nil {
+			if !inPhase {
+				add(n, ProblemWarning, "task outside any phase is ignored")
+				continue
+			}
+			inTask = true
+			if m[3] == "" {
+				if c := trailingCodeRe.FindStringSubmatch(m[2]); c != nil {
+					add(n, ProblemWarning, "%q is not a commit SHA (7-40 lowercase hex characters)", c[1])
+				}
+			}
+			continue
+		}
+		if SubtaskRe.MatchString(line) {
+			if !inTask {
+				add(n, ProblemWarning, "sub-task without a parent task is ignored")
+			}
+			continue
+		}
+
+		m := checkboxRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		indent, mark := m[1], m[2]
+		switch {
+		case indent == "" && strings.Contains(line, "Task:"):
+			add(n, ProblemWarning, `task is not recognised; use "- [ ] Task: name", "- [~]" or "- [x]"`)
+		case indent == "":
+			add(n, ProblemInfo, `checklist item is not tracked; prefix it with "Task:" to make it a task`)
+		case mark == "~":
+			add(n, ProblemWarning, "sub-tasks cannot be marked in progress; use [ ] or [x]")
+		case indent != "    ":
+			add(n, ProblemWarning, "sub-task is not recognised; indent it by exactly 4 spaces")
+		default:
+			add(n, ProblemWarning, "sub-task is not recognised; use [ ] or [x]")
+		}
+	}
+	if phases == 0 {
+		add(0, ProblemInfo, `no phases found; add a "## Phase 1: Name" heading`)
+	}
+	return out
+}
+
+// ValidateMetadata reports syntax errors, missing fields and unexpected
+// values in a metadata.json, checking status and type against StatusValues
+// and TypeValues.
+func ValidateMetadata(raw []byte) []Problem {
+	return ValidateMetadataFor(raw, StatusValues, TypeValues)
+}
+
+// ValidateMetadataFor is ValidateMetadata for a project with its own track
+// statuses and types.
+func ValidateMetadataFor(raw []byte, statuses, types []string) []Problem {
+	var out []Problem
+	add := func(line int, severity, format string, args ...any) {
+		out = append(out, Problem{Line: line, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	var m metadataJSON
+	if err := json.Unmarshal(raw, &m); err != nil {
+		var syntax *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntax):
+			add(offsetLine(raw, syntax.Offset), ProblemError, "invalid JSON: %v", err)
+		case errors.As(err, &typ):
+			add(offsetLine(raw, typ.Offset), ProblemError, "%s must be a %s", fieldName(typ.Field), typ.Type)
+		default:
+			add(0, ProblemError, "invalid JSON: %v", err)
+		}
+		return out
+	}
+
+	for _, f := range []struct{ key, value string }{
+		{"track_id", m.TrackID}, {"type", m.Type}, {"status", m.Status},
+	} {
+		if f.value == "" {
+			add(keyLine(raw, f.key), ProblemWarning, "%s is missing", f.key)
+		}
+	}
+	if m.Description == "" {
+		add(keyLine(raw, "description"), ProblemInfo, "description is missing")
+	}
+	if m.Status != "" && !slices.Contains(statuses, m.Status) {
+		add(keyLine(raw, "status"), ProblemInfo, "status %q is not one of %s", m.Status, strings.Join(statuses, ", "))
+	}
+	if m.Type != "" && !slices.Contains(types, m.Type) {
+		add(keyLine(raw, "type"), ProblemInfo, "type %q is not one of %s", m.Type, strings.Join(types, ", "))
+	}
+	for _, f := range []struct{ key, value string }{
+		{"created_at", m.CreatedAt}, {"updated_at", m.UpdatedAt},
+		{"started_at", m.StartedAt}, {"completed_at", m.CompletedAt}, {"cancelled_at", m.CancelledAt},
+	} {
+		if f.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, f.value); err != nil {
+			add(keyLine(raw, f.key), ProblemWarning, "%s is not an RFC 3339 timestamp and is ignored", f.key)
+		}
+	}
+	return out
+}
+
+func fieldName(f string) string {
+	if f == "" {
+		return "metadata"
+	}
+	return f
+}
+
+// offsetLine returns the 1-based line containing byte offset off.
+func offsetLine(raw []byte, off int64) int {
+	off = min(max(off, 0), int64(len(raw)))
+	return bytes.Count(raw[:off], []byte("\n")) + 1
+}
+
+// keyLine returns the 1-based line of the first "key": in raw, or 0.
+func keyLine(raw []byte, key string) int {
+	i := bytes.Index(raw, []byte(`"`+key+`"`))
+	if i < 0 {
+		return 0
+	}
+	return offsetLine(raw, int64(i))
+}
@@ -0,0 +1,85 @@
+package conductor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Workflow holds the rules for changing a track's status: the statuses
+// each status may move to, and the statuses that mark a track as started,
+// completed or cancelled. Those set started_at, completed_at and
+// cancelled_at in metadata.json. The zero Workflow allows every move and
+// records no timestamps.
+type Workflow struct {
+	// Transitions maps a status to the statuses it may move to. A status
+	// without an entry may move to any other.
+	Transitions map[string][]string `json:"transitions,omitempty"`
+	Started     []string            `json:"started,omitempty"`
+	Completed   []string            `json:"completed,omitempty"`
+	Cancelled   []string            `json:"cancelled,omitempty"`
+}
+
+// DefaultWorkflow allows every move between StatusValues and records the
+// lifecycle timestamps of in_progress, completed and cancelled.
+var DefaultWorkflow = Workflow{
+	Started:   []string{"in_progress"},
+	Completed: []string{"completed"},
+	Cancelled: []string{"cancelled"},
+}
+
+// Allows reports whether a track may move from one status to another.
+func (w Workflow) Allows(from, to string) bool {
+	targets, ok := w.Transitions[from]
+	return from == to || !ok || slices.Contains(targets, to)
+}
+
+// SetStatus moves t to status at now. Entering a started status records
+// started_at unless it is already set; entering a completed or cancelled
+// status records completed_at or cancelled_at. Leaving those clears their
+// timestamp, and moving to a status that is none of them, such as new,
+// clears all three.
+func (w Workflow) SetStatus(t *Track, status string, now time.Time) error {
+	if !w.Allows(t.Status, status) {
+		return fmt.Errorf("status cannot change from %q to %q (allowed: %s)", t.Status, status, strings.Join(w.Transitions[t.Status], ", "))
+	}
+	if status == t.Status {
+		return nil
+	}
+	now = now.UTC().Truncate(time.Second)
+	started := slices.Contains(w.Started, status)
+	completed := slices.Contains(w.Completed, status)
+	cancelled := slices.Contains(w.Cancelled, status)
+	// Timestamps without a list of statuses are left alone.
+	if len(w.Started) > 0 {
+		switch {
+		case started && t.StartedAt.IsZero():
+			t.StartedAt = now
+		case !started && !completed && !cancelled:
+			t.StartedAt = time.Time{}
+		}
+	}
+	if len(w.Completed) > 0 {
+		t.CompletedAt = time.Time{}
+		if completed {
+			t.CompletedAt = now
+		}
+	}
+	if len(w.Cancelled) > 0 {
+		t.CancelledAt = time.Time{}
+		if cancelled {
+			t.CancelledAt = now
+		}
+	}
+	t.Status = status
+	return nil
+}
+
+// SetStatus moves t to status following p.Workflow and saves it.
+func (p Project) SetStatus(t Track, status string) (Track, error) {
+	if err := p.Workflow.SetStatus(&t, status, time.Now()); err != nil {
+		return t, err
+	}
+	return t, p.SaveTrack(t)
+}